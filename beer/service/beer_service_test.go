@@ -11,7 +11,7 @@ import (
 	"github.com/samuskitchen/go-health-checker/configs/cache"
 	events "github.com/samuskitchen/go-health-checker/configs/event"
 	_mockToolsBroker "github.com/samuskitchen/go-health-checker/pkg/tools/mocks/broker"
-	_mockToolsDataStore "github.com/samuskitchen/go-health-checker/pkg/tools/mocks/data_store"
+	_mockToolsDataStore "github.com/samuskitchen/go-health-checker/pkg/tools/mocks/datastore"
 
 	"github.com/samuskitchen/go-health-checker/beer/model"
 
@@ -9,11 +9,15 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/samuskitchen/go-health-checker/configs/cache"
+	events "github.com/samuskitchen/go-health-checker/configs/event"
 	"github.com/samuskitchen/go-health-checker/configs/generals/injector"
 	"github.com/samuskitchen/go-health-checker/configs/generals/router"
+	"github.com/samuskitchen/go-health-checker/configs/health"
 	"github.com/samuskitchen/go-health-checker/configs/storage"
 	"github.com/samuskitchen/go-health-checker/pkg/kit/enums"
 	kitZeroLog "github.com/samuskitchen/go-health-checker/pkg/kit/logger/zerolog"
+	"github.com/samuskitchen/go-health-checker/pkg/tools/healthcheck"
 	serverEcho "github.com/samuskitchen/go-health-checker/pkg/tools/server"
 
 	// Swagger auto-generated documentation
@@ -21,7 +25,9 @@ import (
 
 	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"go.uber.org/dig"
 )
 
 // main
@@ -51,6 +57,11 @@ func main() {
 	debug := flag.Bool("debug", boolVal, "sets log level to debug")
 	kitZeroLog.InitLogger(enums.App, *debug)
 
+	// Run the checks once instead of the server, e.g. for the HEALTHCHECK of the Docker image
+	if len(os.Args) > 1 && os.Args[1] == enums.CheckCommand {
+		os.Exit(runCheck(container, os.Args[2:], *debug))
+	}
+
 	// Configure server times
 	configureServerTimes()
 
@@ -68,14 +79,53 @@ func main() {
 	defer func() {
 		log.Info().Msg("Closing connections...")
 
+		// Stop the background health checks before closing the clients they use
+		health.HealthCheckerStop()
+
 		// Try closing database Postgres and report if there is an error
 		storage.PostgresCloseConnection()
+		cache.CloseConnection()
+		events.RabbitCloseConnection()
 
 		log.Info().Msg("Resource cleanup complete.")
 	}()
 
 }
 
+// runCheck runs the configured checks once, prints their response and returns the exit code of the process
+func runCheck(container *dig.Container, args []string, debug bool) int {
+	flags := flag.NewFlagSet(enums.CheckCommand, flag.ExitOnError)
+	format := flags.String("format", string(healthcheck.FormatJSON), "output format, json or table")
+	strict := flags.Bool("strict", false, "exit with an error when the service is degraded")
+	_ = flags.Parse(args)
+
+	outputFormat, ok := healthcheck.ParseFormat(*format)
+	if !ok {
+		log.Error().Msgf("Error: unknown format %q, expected json or table", *format)
+		return 2
+	}
+
+	// Keeps the output readable, only the problems connecting to the dependencies are logged
+	if !debug {
+		zerolog.SetGlobalLevel(zerolog.WarnLevel)
+	}
+
+	exitCode := 1
+	err := container.Invoke(func(clientPg *storage.Data, clientCache *cache.Cache, clientRabbit *events.RabbitEvent) {
+		exitCode = health.Check(clientPg, clientCache, clientRabbit, os.Stdout, outputFormat, *strict)
+	})
+	if err != nil {
+		log.Error().Msgf("Error running the health checks: %v", err)
+		return 1
+	}
+
+	storage.PostgresCloseConnection()
+	cache.CloseConnection()
+	events.RabbitCloseConnection()
+
+	return exitCode
+}
+
 func configureServerTimes() {
 	serverEcho.SetServersTimeConfiguration(serverEcho.ServersTimeConfiguration{
 		ReadTimeout:  10 * time.Second,
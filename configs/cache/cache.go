@@ -0,0 +1,72 @@
+// Package cache provides a singleton connection to Hazelcast or Redis
+// to manage the application cache.
+package cache
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/samuskitchen/go-health-checker/pkg/kit/enums"
+	"github.com/samuskitchen/go-health-checker/pkg/tools/datastore"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	once      sync.Once
+	dataCache *Cache
+)
+
+// Cache wraps the singleton client of the configured cache provider
+// and exposes the application's caching functionality.
+// Only the client of the configured provider is set.
+type Cache struct {
+	Hazelcast datastore.IClient
+	Redis     datastore.IClient
+}
+
+// Connection returns the singleton Cache instance that maintains the connection
+// to the provider selected by CACHE_PROVIDER, Hazelcast by default.
+// If it isn't already initialized, it creates it.
+func Connection() *Cache {
+	once.Do(getConnection)
+	return dataCache
+}
+
+func getConnection() {
+	switch provider := os.Getenv(enums.CacheProvider); provider {
+	case enums.CacheProviderRedis:
+		dataCache = &Cache{
+			Redis: redisConnection(),
+		}
+	case "", enums.CacheProviderHazelcast:
+		dataCache = &Cache{
+			Hazelcast: hazelcastConnection(),
+		}
+	default:
+		log.Warn().Msgf("Warning: unknown %s %q, using %s", enums.CacheProvider, provider,
+			enums.CacheProviderHazelcast)
+		dataCache = &Cache{
+			Hazelcast: hazelcastConnection(),
+		}
+	}
+}
+
+// CloseConnection closes the cache singleton connection if it has been initialized.
+// Logs fatal on error closing.
+func CloseConnection() {
+	if dataCache == nil {
+		return
+	}
+
+	for _, client := range []datastore.IClient{dataCache.Hazelcast, dataCache.Redis} {
+		if client == nil {
+			continue
+		}
+
+		if err := client.Disconnect(context.Background()); err != nil {
+			log.Fatal().Msgf("Error closing the cache: %v", err)
+		}
+	}
+}
@@ -1,11 +1,7 @@
-// Package cache provides a singleton connection to Hazelcast
-// to manage the application cache.
 package cache
 
 import (
-	"context"
 	"os"
-	"sync"
 
 	"github.com/samuskitchen/go-health-checker/pkg/kit/enums"
 	hazelcast "github.com/samuskitchen/go-health-checker/pkg/tools/datastore"
@@ -14,25 +10,8 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-var (
-	once      sync.Once
-	dataCache *Cache
-)
-
-// Cache wraps the Hazelcast singleton client
-// and exposes the application's caching functionality.
-type Cache struct {
-	Hazelcast hazelcast.IClient
-}
-
-// HazelcastConnection returns the singleton Cache instance that maintains
-// the connection to the Hazelcast cluster. If it isn't already initialized, it creates it.
-func HazelcastConnection() *Cache {
-	once.Do(getConnection)
-	return dataCache
-}
-
-func getConnection() {
+// hazelcastConnection connects to the Hazelcast cluster, nil when it cannot be reached
+func hazelcastConnection() hazelcast.IClient {
 	cacheConfigs := modelCache.Config{
 		ClusterName: enums.HazelClusterName,
 		Addresses:   []string{os.Getenv(enums.HazelServer)},
@@ -42,19 +21,8 @@ func getConnection() {
 	conn, err := hazelcast.NewClientHazelcast(cacheConfigs)
 	if err != nil {
 		log.Error().Msgf("Error connecting to database: %v", err)
+		return nil
 	}
 
-	dataCache = &Cache{
-		Hazelcast: conn,
-	}
-}
-
-// HazelcastCloseConnection closes the Hazelcast singleton connection if it has been initialized.
-// Logs fatal on error closing.
-func HazelcastCloseConnection() {
-	if dataCache != nil {
-		if err := dataCache.Hazelcast.Disconnect(context.Background()); err != nil {
-			log.Fatal().Msgf("Error closing the database: %v", err)
-		}
-	}
+	return conn
 }
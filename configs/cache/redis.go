@@ -0,0 +1,40 @@
+package cache
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/samuskitchen/go-health-checker/pkg/kit/enums"
+	"github.com/samuskitchen/go-health-checker/pkg/tools/datastore"
+	modelCache "github.com/samuskitchen/go-health-checker/pkg/tools/models"
+
+	"github.com/rs/zerolog/log"
+)
+
+// redisConnection connects to the Redis server, nil when it cannot be reached
+func redisConnection() datastore.IClient {
+	cacheConfigs := modelCache.RedisConfig{
+		Address:    os.Getenv(enums.RedisServer),
+		Username:   os.Getenv(enums.RedisUsername),
+		Password:   os.Getenv(enums.RedisPassword),
+		ClientName: enums.RedisClientName,
+		TLS:        os.Getenv(enums.RedisTLS) == "true",
+	}
+
+	if value := os.Getenv(enums.RedisDB); value != "" {
+		db, err := strconv.Atoi(value)
+		if err != nil || db < 0 {
+			log.Warn().Msgf("Warning: %s must be a positive number, using database 0", enums.RedisDB)
+		} else {
+			cacheConfigs.DB = db
+		}
+	}
+
+	conn, err := datastore.NewClientRedis(cacheConfigs)
+	if err != nil {
+		log.Error().Msgf("Error connecting to Redis: %v", err)
+		return nil
+	}
+
+	return conn
+}
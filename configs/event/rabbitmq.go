@@ -28,6 +28,18 @@ func RabbitConnection() *RabbitEvent {
 	return rabbitClient
 }
 
+// RabbitCloseConnection closes the RabbitMQ singleton connection if it has been initialized.
+// Logs an error on closing, as a broker that went away cannot be closed cleanly.
+func RabbitCloseConnection() {
+	if rabbitClient == nil || rabbitClient.RabbitMQClient == nil {
+		return
+	}
+
+	if err := rabbitClient.RabbitMQClient.Close(); err != nil {
+		log.Error().Msgf("Error closing RabbitMQ: %v", err)
+	}
+}
+
 // NewRabbitEvent is a clean constructor for RabbitEvent, compatible with dig
 func getConnectionRabbit() {
 	client := libRabbitmq.NewClient()
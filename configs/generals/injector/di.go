@@ -10,6 +10,8 @@ import (
 	"github.com/samuskitchen/go-health-checker/configs/cache"
 	events "github.com/samuskitchen/go-health-checker/configs/event"
 	"github.com/samuskitchen/go-health-checker/configs/generals/router"
+	"github.com/samuskitchen/go-health-checker/configs/health"
+	"github.com/samuskitchen/go-health-checker/configs/metrics"
 	"github.com/samuskitchen/go-health-checker/configs/storage"
 	echo "github.com/samuskitchen/go-health-checker/pkg/tools/server"
 
@@ -25,7 +27,7 @@ func BuildContainer() *dig.Container {
 
 	// DB / Cache
 	checkError(Container.Provide(storage.PostgresConnection))
-	checkError(Container.Provide(cache.HazelcastConnection))
+	checkError(Container.Provide(cache.Connection))
 
 	// Broker
 	checkError(Container.Provide(events.RabbitConnection))
@@ -34,7 +36,11 @@ func BuildContainer() *dig.Container {
 	checkError(Container.Provide(echo.NewServer))
 	checkError(Container.Provide(router.NewRouter))
 
+	// Metrics
+	checkError(Container.Provide(metrics.Registry))
+
 	// Health Check
+	checkError(Container.Provide(health.HealthChecker))
 	checkError(Container.Provide(router.NewHealthHandler))
 
 	// Handlers
@@ -0,0 +1,125 @@
+package router
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samuskitchen/go-health-checker/pkg/kit/enums"
+	"github.com/samuskitchen/go-health-checker/pkg/tools/healthcheck"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:embed templates/dashboard.html
+var templates embed.FS
+
+// dashboardTemplate renders the status dashboard, it is self-contained and loads no external asset
+var dashboardTemplate = template.Must(template.New("dashboard.html").Funcs(template.FuncMap{
+	"statusClass": statusClass,
+	"formatTime":  formatTime,
+	"percent":     func(score float64) float64 { return score * 100 },
+}).ParseFS(templates, "templates/dashboard.html"))
+
+// dashboardPage is the data rendered by the status dashboard
+type dashboardPage struct {
+	healthcheck.Response
+	App string
+	// Refresh is the number of seconds between two reloads of the page, zero disables them
+	Refresh    int
+	Components []dashboardComponent
+}
+
+// dashboardComponent is a row of the status dashboard, the latest result of a check and its history
+type dashboardComponent struct {
+	healthcheck.Health
+	healthcheck.ComponentHistory
+}
+
+// Dashboard serves a status page for people, reloading itself to follow the latest snapshot
+// @Description HTML status page with the status, latency, last change, last error and recent history of each component
+// @Tags Health
+// @ID dashboard
+// @Produce html
+// @Param fresh query bool false "Run the checks live instead of serving the latest snapshot"
+// @Param verbosity query string false "Use summary to hide the errors of each check" Enums(full, summary)
+// @Success 200 {string} string "HTML page"
+// @Router /status [get]
+func (hh *healthHandler) Dashboard(c echo.Context) error {
+	resp := hh.checkerHealth(c)
+	summary := hh.requestVerbosity(c) == healthcheck.VerbositySummary
+
+	page := dashboardPage{Response: resp, App: enums.App, Refresh: int(hh.refresh.Seconds())}
+	for _, check := range resp.Checks {
+		component := dashboardComponent{Health: check}
+		if hh.history != nil {
+			component.ComponentHistory, _ = hh.history.Component(check.Component)
+		}
+		if summary {
+			component.LastError, component.LastErrorAt = "", nil
+		}
+
+		page.Components = append(page.Components, component)
+	}
+
+	var body bytes.Buffer
+	if err := dashboardTemplate.Execute(&body, page); err != nil {
+		return err
+	}
+
+	return c.HTMLBlob(http.StatusOK, body.Bytes())
+}
+
+// statusClass returns the CSS class coloring a status on the dashboard
+func statusClass(status healthcheck.Status) string {
+	switch status {
+	case healthcheck.StatusOK, healthcheck.StatusAvailable:
+		return "ok"
+	case healthcheck.StatusDegraded:
+		return "degraded"
+	case healthcheck.StatusUnknown, "":
+		return "unknown"
+	default:
+		return "down"
+	}
+}
+
+// formatTime formats a time.Time or *time.Time for the dashboard, empty when it is not set
+func formatTime(value any) string {
+	var t time.Time
+	switch v := value.(type) {
+	case time.Time:
+		t = v
+	case *time.Time:
+		if v != nil {
+			t = *v
+		}
+	}
+
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format("2006-01-02 15:04:05 MST")
+}
+
+// dashboardRefresh reads how often the dashboard reloads, zero disables the reloads
+func dashboardRefresh() time.Duration {
+	value := os.Getenv(enums.HealthDashboardRefresh)
+	if value == "" {
+		return enums.HealthDashboardDefaultRefresh
+	}
+
+	refresh, err := time.ParseDuration(value)
+	if err != nil || refresh < 0 {
+		log.Warn().Msgf("Warning: %s must be a positive duration, using %v", enums.HealthDashboardRefresh,
+			enums.HealthDashboardDefaultRefresh)
+		return enums.HealthDashboardDefaultRefresh
+	}
+
+	return refresh
+}
@@ -0,0 +1,59 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/samuskitchen/go-health-checker/configs/cache"
+	events "github.com/samuskitchen/go-health-checker/configs/event"
+	"github.com/samuskitchen/go-health-checker/configs/health"
+	"github.com/samuskitchen/go-health-checker/configs/storage"
+	"github.com/samuskitchen/go-health-checker/pkg/kit/enums"
+	_mockToolsBroker "github.com/samuskitchen/go-health-checker/pkg/tools/mocks/broker"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDashboard(t *testing.T) {
+	mockBroker := _mockToolsBroker.NewMockClient(t)
+	mockBroker.On("Ping").Return(errors.New("rabbitmq connection is closed"))
+
+	checker := health.NewChecker(&storage.Data{}, &cache.Cache{}, &events.RabbitEvent{RabbitMQClient: mockBroker})
+
+	t.Run("full", func(t *testing.T) {
+		t.Setenv(enums.HealthDashboardRefresh, "30s")
+		hHandler := NewHealthHandler(checker)
+
+		// The history follows the runs of the background polling, not the requests
+		checker.Scheduler.Run(context.Background())
+		checker.Scheduler.Run(context.Background())
+
+		ctx := SetupHTTPContextHealth("GET", enums.DashboardPath, "")
+		assert.NoError(t, hHandler.Dashboard(ctx.context))
+
+		assert.Equal(t, http.StatusOK, ctx.Res.Code)
+		assert.Contains(t, ctx.Res.Header().Get("Content-Type"), "text/html")
+
+		body := ctx.Res.Body.String()
+		assert.Contains(t, body, `<meta http-equiv="refresh" content="30">`)
+		assert.Contains(t, body, "RabbitMQ")
+		assert.Contains(t, body, "rabbitmq connection is closed")
+		assert.Equal(t, 2, strings.Count(body, `<span class="down" title=`), "one sample per run")
+		assert.NotContains(t, body, "<script", "the page loads no script")
+		assert.NotContains(t, body, "http://", "the page loads no external asset")
+	})
+
+	t.Run("summary", func(t *testing.T) {
+		t.Setenv(enums.HealthDashboardRefresh, "0s")
+		ctx := SetupHTTPContextHealth("GET", enums.DashboardPath+"?verbosity=summary", "")
+
+		assert.NoError(t, NewHealthHandler(checker).Dashboard(ctx.context))
+
+		body := ctx.Res.Body.String()
+		assert.NotContains(t, body, "rabbitmq connection is closed")
+		assert.NotContains(t, body, `http-equiv="refresh"`)
+	})
+}
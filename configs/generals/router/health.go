@@ -1,52 +1,299 @@
 package router
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
 
 	"github.com/labstack/echo/v4"
-	"github.com/samuskitchen/go-health-checker/configs/cache"
-	events "github.com/samuskitchen/go-health-checker/configs/event"
-	"github.com/samuskitchen/go-health-checker/configs/storage"
+	"github.com/samuskitchen/go-health-checker/configs/health"
+	"github.com/samuskitchen/go-health-checker/pkg/kit/enums"
 	"github.com/samuskitchen/go-health-checker/pkg/tools/healthcheck"
+
+	"github.com/rs/zerolog/log"
 )
 
 type healthHandler struct {
-	clientPg        *storage.Data
-	clientHazelcast *cache.Cache
-	clientRabbit    *events.RabbitEvent
+	scheduler   *healthcheck.Scheduler
+	fleet       *healthcheck.Aggregator
+	history     *healthcheck.History
+	refresh     time.Duration
+	stream      *healthcheck.Stream
+	heartbeat   time.Duration
+	statusCodes healthcheck.StatusCodes
+	verbosity   healthcheck.Verbosity
+	started     atomic.Bool
+}
+
+// probeResponse is the body returned by the liveness and startup probes
+type probeResponse struct {
+	Status    string `json:"status"`
+	Timestamp string `json:"timestamp"`
+}
+
+// errorResponse is the body returned when a fleet resource does not exist
+type errorResponse struct {
+	Message string `json:"message"`
 }
 
-// HealthHandler defines the interface for the health check endpoint
+// HealthHandler defines the interface for the health check endpoints
 type HealthHandler interface {
 	HealthChecker(c echo.Context) error
+	Liveness(c echo.Context) error
+	Readiness(c echo.Context) error
+	Startup(c echo.Context) error
+	Fleet(c echo.Context) error
+	FleetService(c echo.Context) error
+	FleetComponent(c echo.Context) error
+	Dashboard(c echo.Context) error
+	Stream(c echo.Context) error
 }
 
 // NewHealthHandler builds a new HealthHandler
-func NewHealthHandler(clientPg *storage.Data, clientHazelcast *cache.Cache,
-	clientRabbit *events.RabbitEvent,
-) HealthHandler {
+func NewHealthHandler(checker *health.Checker) HealthHandler {
 	return &healthHandler{
-		clientPg:        clientPg,
-		clientHazelcast: clientHazelcast,
-		clientRabbit:    clientRabbit,
+		scheduler:   checker.Scheduler,
+		fleet:       checker.Fleet,
+		history:     checker.History,
+		refresh:     dashboardRefresh(),
+		stream:      checker.Stream,
+		heartbeat:   streamHeartbeat(),
+		statusCodes: healthStatusCodes(),
+		verbosity:   healthVerbosity(),
 	}
 }
 
 // HealthChecker checks the health of the service
-// @Description Check if service is up and healthy
+// @Description Check if service is up and healthy. Serves the latest background snapshot unless fresh=true.
 // @Tags Health
 // @ID finance
-// @Success 200 {object} health.Response
-// @Failure 404
+// @Param fresh query bool false "Run the checks live instead of serving the latest snapshot"
+// @Param verbosity query string false "Use summary to hide the details of each check" Enums(full, summary)
+// @Success 200 {object} healthcheck.Response
+// @Success 207 {object} healthcheck.Response
+// @Failure 503 {object} healthcheck.Response
 // @Router /health [get]
 func (hh *healthHandler) HealthChecker(c echo.Context) error {
-	ctx := c.Request().Context()
+	resp := hh.checkerHealth(c)
+	return c.JSON(hh.statusCodes.HTTPStatus(resp.OverallStatus), resp)
+}
+
+// Liveness reports whether the process is able to serve requests.
+// It does not touch any dependency, it only fails when the background polling is stalled.
+// @Description Liveness probe, fails only when the process is wedged
+// @Tags Health
+// @ID liveness
+// @Success 200 {object} probeResponse
+// @Failure 503 {object} probeResponse
+// @Router /health/live [get]
+func (hh *healthHandler) Liveness(c echo.Context) error {
+	if hh.scheduler.Stalled() {
+		return c.JSON(http.StatusServiceUnavailable, newProbeResponse("STALLED"))
+	}
+
+	return c.JSON(http.StatusOK, newProbeResponse("UP"))
+}
+
+// Readiness reports whether the service can take traffic
+// @Description Readiness probe, fails when the dependencies are unavailable
+// @Tags Health
+// @ID readiness
+// @Param fresh query bool false "Run the checks live instead of serving the latest snapshot"
+// @Param verbosity query string false "Use summary to hide the details of each check" Enums(full, summary)
+// @Success 200 {object} healthcheck.Response
+// @Success 207 {object} healthcheck.Response
+// @Failure 503 {object} healthcheck.Response
+// @Router /health/ready [get]
+func (hh *healthHandler) Readiness(c echo.Context) error {
+	resp := hh.checkerHealth(c)
+	return c.JSON(hh.statusCodes.HTTPStatus(resp.OverallStatus), resp)
+}
+
+// Startup reports whether the service finished warming up, that is,
+// whether the dependencies have been reachable at least once since the process started
+// @Description Startup probe, fails until the dependencies have been reachable once
+// @Tags Health
+// @ID startup
+// @Success 200 {object} probeResponse
+// @Failure 503 {object} healthcheck.Response
+// @Router /health/startup [get]
+func (hh *healthHandler) Startup(c echo.Context) error {
+	if hh.started.Load() {
+		return c.JSON(http.StatusOK, newProbeResponse("STARTED"))
+	}
+
+	resp := hh.checkerHealth(c)
+	if resp.OverallStatus == healthcheck.StatusUnavailable {
+		return c.JSON(http.StatusServiceUnavailable, resp)
+	}
+
+	hh.started.Store(true)
+	return c.JSON(http.StatusOK, newProbeResponse("STARTED"))
+}
+
+// Fleet reports the combined health of the remote services polled in aggregator mode
+// @Description Combined health of the remote services and overall fleet status. Serves the latest background snapshot unless fresh=true.
+// @Tags Health
+// @ID fleet
+// @Param fresh query bool false "Poll the services live instead of serving the latest snapshot"
+// @Param verbosity query string false "Use summary to hide the details of each service" Enums(full, summary)
+// @Success 200 {object} healthcheck.FleetResponse
+// @Success 207 {object} healthcheck.FleetResponse
+// @Failure 404 {object} errorResponse
+// @Failure 503 {object} healthcheck.FleetResponse
+// @Router /health/fleet [get]
+func (hh *healthHandler) Fleet(c echo.Context) error {
+	if hh.fleet == nil {
+		return c.JSON(http.StatusNotFound, errorResponse{Message: "aggregator mode is disabled"})
+	}
+
+	resp := hh.fleetHealth(c)
+	return c.JSON(hh.statusCodes.HTTPStatus(resp.OverallStatus), resp)
+}
+
+// FleetService reports the health of a single remote service and of its components
+// @Description Health of a remote service as polled by the aggregator
+// @Tags Health
+// @ID fleet-service
+// @Param service path string true "Name of the remote service"
+// @Param fresh query bool false "Poll the services live instead of serving the latest snapshot"
+// @Param verbosity query string false "Use summary to hide the details of the service" Enums(full, summary)
+// @Success 200 {object} healthcheck.ServiceHealth
+// @Success 207 {object} healthcheck.ServiceHealth
+// @Failure 404 {object} errorResponse
+// @Failure 503 {object} healthcheck.ServiceHealth
+// @Router /health/fleet/{service} [get]
+func (hh *healthHandler) FleetService(c echo.Context) error {
+	service, err := hh.fleetService(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, errorResponse{Message: err.Error()})
+	}
+
+	return c.JSON(hh.statusCodes.CheckHTTPStatus(service.Status), service)
+}
+
+// FleetComponent reports the health of a single component of a remote service
+// @Description Health of a component as reported by a remote service
+// @Tags Health
+// @ID fleet-component
+// @Param service path string true "Name of the remote service"
+// @Param component path string true "Name of the component reported by the service"
+// @Param fresh query bool false "Poll the services live instead of serving the latest snapshot"
+// @Param verbosity query string false "Use summary to hide the details of the component" Enums(full, summary)
+// @Success 200 {object} healthcheck.Health
+// @Success 207 {object} healthcheck.Health
+// @Failure 404 {object} errorResponse
+// @Failure 503 {object} healthcheck.Health
+// @Router /health/fleet/{service}/{component} [get]
+func (hh *healthHandler) FleetComponent(c echo.Context) error {
+	service, err := hh.fleetService(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, errorResponse{Message: err.Error()})
+	}
+
+	name := c.Param(enums.HealthFleetComponentParam)
+	component, ok := service.Component(name)
+	if !ok {
+		return c.JSON(http.StatusNotFound, errorResponse{
+			Message: fmt.Sprintf("service %q did not report component %q", service.Service, name),
+		})
+	}
+
+	return c.JSON(hh.statusCodes.CheckHTTPStatus(component.Status), component)
+}
+
+// fleetService looks up the service named in the path of the request
+func (hh *healthHandler) fleetService(c echo.Context) (healthcheck.ServiceHealth, error) {
+	if hh.fleet == nil {
+		return healthcheck.ServiceHealth{}, errors.New("aggregator mode is disabled")
+	}
+
+	name := c.Param(enums.HealthFleetServiceParam)
+	service, ok := hh.fleetHealth(c).Service(name)
+	if !ok {
+		return healthcheck.ServiceHealth{}, fmt.Errorf("service %q is not polled", name)
+	}
+
+	return service, nil
+}
+
+// fleetHealth serves the latest snapshot of the remote services,
+// polling them live when there is none yet or the caller asked for fresh results
+func (hh *healthHandler) fleetHealth(c echo.Context) healthcheck.FleetResponse {
+	fresh, _ := strconv.ParseBool(c.QueryParam(enums.HealthFreshParam))
+	return hh.fleet.Fleet(c.Request().Context(), fresh).WithVerbosity(hh.requestVerbosity(c))
+}
+
+// checkerHealth serves the latest snapshot of the background polling,
+// running the checks live when there is none yet or the caller asked for fresh results
+func (hh *healthHandler) checkerHealth(c echo.Context) healthcheck.Response {
+	return hh.response(c).WithVerbosity(hh.requestVerbosity(c))
+}
+
+// response returns the snapshot or a live run as asked by the caller
+func (hh *healthHandler) response(c echo.Context) healthcheck.Response {
+	fresh, _ := strconv.ParseBool(c.QueryParam(enums.HealthFreshParam))
+	if !fresh {
+		if resp, ok := hh.scheduler.Snapshot(); ok {
+			return resp
+		}
+	}
+
+	return hh.scheduler.Check(c.Request().Context())
+}
+
+// requestVerbosity lets callers ask for a summary, they can never get more detail than configured
+func (hh *healthHandler) requestVerbosity(c echo.Context) healthcheck.Verbosity {
+	if verbosity, _ := healthcheck.ParseVerbosity(c.QueryParam(enums.HealthVerbosityParam)); verbosity ==
+		healthcheck.VerbositySummary {
+		return verbosity
+	}
+
+	return hh.verbosity
+}
+
+// newProbeResponse builds a probe body with the current timestamp
+func newProbeResponse(status string) probeResponse {
+	return probeResponse{
+		Status:    status,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+}
+
+// healthStatusCodes reads the HTTP status code returned while degraded, only 200 and 207 are accepted
+func healthStatusCodes() healthcheck.StatusCodes {
+	value := os.Getenv(enums.HealthDegradedStatusCode)
+	if value == "" {
+		return healthcheck.StatusCodes{Degraded: http.StatusOK}
+	}
+
+	code, err := strconv.Atoi(value)
+	if err != nil || (code != http.StatusOK && code != http.StatusMultiStatus) {
+		log.Warn().Msgf("Warning: %s must be %d or %d, using %d", enums.HealthDegradedStatusCode,
+			http.StatusOK, http.StatusMultiStatus, http.StatusOK)
+		return healthcheck.StatusCodes{Degraded: http.StatusOK}
+	}
+
+	return healthcheck.StatusCodes{Degraded: code}
+}
+
+// healthVerbosity reads the detail of the health responses, full by default
+func healthVerbosity() healthcheck.Verbosity {
+	value := os.Getenv(enums.HealthVerbosity)
+	if value == "" {
+		return healthcheck.VerbosityFull
+	}
 
-	clients := healthcheck.Clients{
-		RabbitClient:    hh.clientRabbit.RabbitMQClient,
-		HazelcastClient: hh.clientHazelcast.Hazelcast,
-		PgClient:        hh.clientPg.DB,
+	verbosity, ok := healthcheck.ParseVerbosity(value)
+	if !ok {
+		log.Warn().Msgf("Warning: %s must be %s or %s, using %s", enums.HealthVerbosity,
+			healthcheck.VerbosityFull, healthcheck.VerbositySummary, healthcheck.VerbositySummary)
+		return healthcheck.VerbositySummary
 	}
 
-	return c.JSON(http.StatusOK, clients.CheckerHealth(ctx))
+	return verbosity
 }
@@ -3,15 +3,20 @@ package router
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/samuskitchen/go-health-checker/configs/cache"
 	events "github.com/samuskitchen/go-health-checker/configs/event"
+	"github.com/samuskitchen/go-health-checker/configs/health"
 	"github.com/samuskitchen/go-health-checker/configs/storage"
 	"github.com/samuskitchen/go-health-checker/pkg/kit/enums"
+	_mockToolsBroker "github.com/samuskitchen/go-health-checker/pkg/tools/mocks/broker"
+	_mockToolsDataStore "github.com/samuskitchen/go-health-checker/pkg/tools/mocks/datastore"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -24,7 +29,7 @@ type HTTPContextHealth struct {
 }
 
 func SetupHTTPContextHealth(method string, url string, body interface{}) HTTPContextHealth {
-	path := fmt.Sprintf("%s%s", enums.BasePath, url)
+	path := fmt.Sprintf("/%s%s", enums.BasePath, url)
 	requestByte, _ := json.Marshal(body)
 	requestReader := bytes.NewReader(requestByte)
 	e := echo.New()
@@ -42,9 +47,231 @@ func TestHealthCheck(t *testing.T) {
 	cacheHazelcast := &cache.Cache{}
 	rabbitClient := &events.RabbitEvent{}
 
-	hHandler := NewHealthHandler(&dbData, cacheHazelcast, rabbitClient)
+	hHandler := NewHealthHandler(health.NewChecker(&dbData, cacheHazelcast, rabbitClient))
 
 	err := hHandler.HealthChecker(ctx.context)
 
 	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, ctx.Res.Code)
+}
+
+func TestHealthCheck_StatusCodes(t *testing.T) {
+	t.Run("unavailable", func(t *testing.T) {
+		ctx := SetupHTTPContextHealth("GET", enums.HealthPath, "")
+
+		mockBroker := _mockToolsBroker.NewMockClient(t)
+		mockBroker.On("Ping").Return(errors.New("rabbitmq connection is closed"))
+
+		checker := health.NewChecker(&storage.Data{}, &cache.Cache{}, &events.RabbitEvent{RabbitMQClient: mockBroker})
+		hHandler := NewHealthHandler(checker)
+
+		assert.NoError(t, hHandler.HealthChecker(ctx.context))
+		assert.Equal(t, http.StatusServiceUnavailable, ctx.Res.Code)
+	})
+
+	t.Run("degraded", func(t *testing.T) {
+		t.Setenv(enums.HealthDegradedStatusCode, "207")
+		ctx := SetupHTTPContextHealth("GET", enums.HealthPath, "")
+
+		mockBroker := _mockToolsBroker.NewMockClient(t)
+		mockBroker.On("Ping").Return(nil)
+		mockBroker.On("ServerVersion").Return("RabbitMQ 3.13.7", nil)
+		mockDataStore := _mockToolsDataStore.NewMockIClient(t)
+		mockDataStore.On("Ping").Return(errors.New("hazelcast client is not running"))
+
+		checker := health.NewChecker(&storage.Data{}, &cache.Cache{Hazelcast: mockDataStore},
+			&events.RabbitEvent{RabbitMQClient: mockBroker})
+		hHandler := NewHealthHandler(checker)
+
+		assert.NoError(t, hHandler.HealthChecker(ctx.context))
+		assert.Equal(t, http.StatusMultiStatus, ctx.Res.Code)
+	})
+}
+
+func TestLiveness(t *testing.T) {
+	ctx := SetupHTTPContextHealth("GET", enums.HealthLivePath, "")
+
+	checker := health.NewChecker(&storage.Data{}, &cache.Cache{}, &events.RabbitEvent{})
+	hHandler := NewHealthHandler(checker)
+
+	err := hHandler.Liveness(ctx.context)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, ctx.Res.Code)
+}
+
+func TestReadiness(t *testing.T) {
+	t.Run("ready", func(t *testing.T) {
+		ctx := SetupHTTPContextHealth("GET", enums.HealthReadyPath, "")
+
+		mockBroker := _mockToolsBroker.NewMockClient(t)
+		mockBroker.On("Ping").Return(nil)
+		mockBroker.On("ServerVersion").Return("RabbitMQ 3.13.7", nil)
+
+		checker := health.NewChecker(&storage.Data{}, &cache.Cache{}, &events.RabbitEvent{RabbitMQClient: mockBroker})
+		hHandler := NewHealthHandler(checker)
+
+		err := hHandler.Readiness(ctx.context)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, ctx.Res.Code)
+		assert.Contains(t, ctx.Res.Body.String(), `"version":"RabbitMQ 3.13.7"`)
+	})
+
+	t.Run("not ready", func(t *testing.T) {
+		ctx := SetupHTTPContextHealth("GET", enums.HealthReadyPath, "")
+
+		mockBroker := _mockToolsBroker.NewMockClient(t)
+		mockBroker.On("Ping").Return(errors.New("rabbitmq connection is closed"))
+
+		checker := health.NewChecker(&storage.Data{}, &cache.Cache{}, &events.RabbitEvent{RabbitMQClient: mockBroker})
+		hHandler := NewHealthHandler(checker)
+
+		err := hHandler.Readiness(ctx.context)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, ctx.Res.Code)
+	})
+}
+
+func TestStartup(t *testing.T) {
+	mockBroker := _mockToolsBroker.NewMockClient(t)
+	mockBroker.On("Ping").Return(errors.New("rabbitmq connection is closed")).Once()
+	mockBroker.On("Ping").Return(nil).Once()
+	mockBroker.On("ServerVersion").Return("RabbitMQ 3.13.7", nil).Once()
+
+	checker := health.NewChecker(&storage.Data{}, &cache.Cache{}, &events.RabbitEvent{RabbitMQClient: mockBroker})
+	hHandler := NewHealthHandler(checker)
+
+	// Dependencies are not reachable yet
+	ctx := SetupHTTPContextHealth("GET", enums.HealthStartupPath, "")
+	assert.NoError(t, hHandler.Startup(ctx.context))
+	assert.Equal(t, http.StatusServiceUnavailable, ctx.Res.Code)
+
+	// Dependencies become reachable, warm-up finishes
+	ctx = SetupHTTPContextHealth("GET", enums.HealthStartupPath, "")
+	assert.NoError(t, hHandler.Startup(ctx.context))
+	assert.Equal(t, http.StatusOK, ctx.Res.Code)
+
+	// Once started the dependencies are no longer checked
+	ctx = SetupHTTPContextHealth("GET", enums.HealthStartupPath, "")
+	assert.NoError(t, hHandler.Startup(ctx.context))
+	assert.Equal(t, http.StatusOK, ctx.Res.Code)
+}
+
+func TestHealthCheck_Snapshot(t *testing.T) {
+	t.Setenv(enums.HealthCheckInterval, "1h")
+
+	mockBroker := _mockToolsBroker.NewMockClient(t)
+	mockBroker.On("Ping").Return(nil)
+	mockBroker.On("ServerVersion").Return("RabbitMQ 3.13.7", nil)
+
+	checker := health.NewChecker(&storage.Data{}, &cache.Cache{}, &events.RabbitEvent{RabbitMQClient: mockBroker})
+	checker.Scheduler.Start()
+	t.Cleanup(checker.Scheduler.Stop)
+
+	assert.Eventually(t, func() bool {
+		_, ok := checker.Scheduler.Snapshot()
+		return ok
+	}, time.Second, 10*time.Millisecond)
+
+	hHandler := NewHealthHandler(checker)
+
+	// Served from the background snapshot, no extra ping
+	ctx := SetupHTTPContextHealth("GET", enums.HealthPath, "")
+	assert.NoError(t, hHandler.HealthChecker(ctx.context))
+	assert.Equal(t, http.StatusOK, ctx.Res.Code)
+	mockBroker.AssertNumberOfCalls(t, "Ping", 1)
+
+	// fresh=true forces a live run
+	ctx = SetupHTTPContextHealth("GET", enums.HealthPath+"?fresh=true", "")
+	assert.NoError(t, hHandler.HealthChecker(ctx.context))
+	assert.Equal(t, http.StatusOK, ctx.Res.Code)
+	mockBroker.AssertNumberOfCalls(t, "Ping", 2)
+}
+
+func TestHealthCheck_Verbosity(t *testing.T) {
+	mockBroker := _mockToolsBroker.NewMockClient(t)
+	mockBroker.On("Ping").Return(errors.New("rabbitmq connection is closed"))
+
+	checker := health.NewChecker(&storage.Data{}, &cache.Cache{}, &events.RabbitEvent{RabbitMQClient: mockBroker})
+
+	t.Run("full", func(t *testing.T) {
+		ctx := SetupHTTPContextHealth("GET", enums.HealthPath, "")
+
+		assert.NoError(t, NewHealthHandler(checker).HealthChecker(ctx.context))
+		assert.Contains(t, ctx.Res.Body.String(), "rabbitmq connection is closed")
+	})
+
+	t.Run("summary requested", func(t *testing.T) {
+		ctx := SetupHTTPContextHealth("GET", enums.HealthPath+"?verbosity=summary", "")
+
+		assert.NoError(t, NewHealthHandler(checker).HealthChecker(ctx.context))
+		assert.NotContains(t, ctx.Res.Body.String(), "rabbitmq connection is closed")
+	})
+
+	t.Run("summary configured", func(t *testing.T) {
+		t.Setenv(enums.HealthVerbosity, "summary")
+		ctx := SetupHTTPContextHealth("GET", enums.HealthPath+"?verbosity=full", "")
+
+		assert.NoError(t, NewHealthHandler(checker).HealthChecker(ctx.context))
+		assert.NotContains(t, ctx.Res.Body.String(), "rabbitmq connection is closed")
+	})
+}
+
+func TestFleet(t *testing.T) {
+	catalog := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"overallStatus":"Unavailable","checks":[` +
+			`{"component":"postgresql-sql","status":"Unavailable","critical":true,"error":"connection refused"},` +
+			`{"component":"Redis","status":"OK"}]}`))
+	}))
+	defer catalog.Close()
+
+	t.Run("disabled", func(t *testing.T) {
+		ctx := SetupHTTPContextHealth("GET", enums.HealthFleetPath, "")
+		hHandler := NewHealthHandler(health.NewChecker(&storage.Data{}, &cache.Cache{}, &events.RabbitEvent{}))
+
+		assert.NoError(t, hHandler.Fleet(ctx.context))
+		assert.Equal(t, http.StatusNotFound, ctx.Res.Code)
+	})
+
+	t.Setenv(enums.HealthFleetServices, "catalog="+catalog.URL)
+	hHandler := NewHealthHandler(health.NewChecker(&storage.Data{}, &cache.Cache{}, &events.RabbitEvent{}))
+
+	t.Run("fleet", func(t *testing.T) {
+		ctx := SetupHTTPContextHealth("GET", enums.HealthFleetPath, "")
+
+		assert.NoError(t, hHandler.Fleet(ctx.context))
+		assert.Equal(t, http.StatusServiceUnavailable, ctx.Res.Code)
+		assert.Contains(t, ctx.Res.Body.String(), `"service":"catalog"`)
+	})
+
+	t.Run("service", func(t *testing.T) {
+		ctx := SetupHTTPContextHealth("GET", enums.HealthFleetPath+"/catalog", "")
+		ctx.context.SetParamNames(enums.HealthFleetServiceParam)
+		ctx.context.SetParamValues("catalog")
+
+		assert.NoError(t, hHandler.FleetService(ctx.context))
+		assert.Equal(t, http.StatusServiceUnavailable, ctx.Res.Code)
+		assert.Contains(t, ctx.Res.Body.String(), "connection refused")
+	})
+
+	t.Run("component", func(t *testing.T) {
+		ctx := SetupHTTPContextHealth("GET", enums.HealthFleetPath+"/catalog/Redis", "")
+		ctx.context.SetParamNames(enums.HealthFleetServiceParam, enums.HealthFleetComponentParam)
+		ctx.context.SetParamValues("catalog", "Redis")
+
+		assert.NoError(t, hHandler.FleetComponent(ctx.context))
+		assert.Equal(t, http.StatusOK, ctx.Res.Code)
+	})
+
+	t.Run("unknown service", func(t *testing.T) {
+		ctx := SetupHTTPContextHealth("GET", enums.HealthFleetPath+"/orders/Redis", "")
+		ctx.context.SetParamNames(enums.HealthFleetServiceParam, enums.HealthFleetComponentParam)
+		ctx.context.SetParamValues("orders", "Redis")
+
+		assert.NoError(t, hHandler.FleetComponent(ctx.context))
+		assert.Equal(t, http.StatusNotFound, ctx.Res.Code)
+	})
 }
@@ -9,12 +9,14 @@ import (
 	"github.com/samuskitchen/go-health-checker/beer/handler"
 	"github.com/samuskitchen/go-health-checker/pkg/kit/enums"
 	kitZeroLog "github.com/samuskitchen/go-health-checker/pkg/kit/logger/zerolog"
+	kitMetrics "github.com/samuskitchen/go-health-checker/pkg/kit/metrics"
 
 	// Echo es el framework web utilizado para definir rutas y handlers.
 	echoSwagger "github.com/swaggo/echo-swagger"
 
 	"github.com/labstack/echo/v4"
 	middlewareEcho "github.com/labstack/echo/v4/middleware"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
@@ -24,14 +26,17 @@ type Router struct {
 	server        *echo.Echo
 	beerHandler   handler.BeerHandler // Handler que delega la lógica de BeerService
 	healthHandler HealthHandler
+	registry      *prometheus.Registry // Registry served on the metrics endpoint
 }
 
 // NewRouter constructor for routing with echo-go
-func NewRouter(server *echo.Echo, beerHandler handler.BeerHandler, healthHandler HealthHandler) *Router {
+func NewRouter(server *echo.Echo, beerHandler handler.BeerHandler, healthHandler HealthHandler,
+	registry *prometheus.Registry) *Router {
 	return &Router{
 		server:        server,
 		beerHandler:   beerHandler,
 		healthHandler: healthHandler,
+		registry:      registry,
 	}
 }
 
@@ -50,18 +55,37 @@ func (r *Router) Init() {
 			"status": "@status",
 		},
 		Skipper: func(c echo.Context) bool {
-			return strings.Contains(c.Request().URL.Path, enums.HealthPath)
+			return strings.Contains(c.Request().URL.Path, enums.HealthPath) || c.Path() == enums.MetricsPath
+		},
+	}
+
+	metricsConfig := kitMetrics.Config{
+		Registerer: r.registry,
+		// The stream connections last as long as their clients stay, they would skew the request latencies
+		Skipper: func(c echo.Context) bool {
+			return c.Path() == enums.MetricsPath || strings.HasSuffix(c.Path(), enums.HealthStreamPath)
 		},
 	}
 
 	r.server.Use(kitZeroLog.LogWithConfig(logConfig))
+	r.server.Use(kitMetrics.MiddlewareWithConfig(metricsConfig))
 	r.server.Use(middlewareEcho.Recover())
 	r.server.Use(middlewareEcho.RequestID())
 
+	r.server.GET(enums.MetricsPath, kitMetrics.Handler(r.registry))
+
 	apiGroup := r.server.Group(enums.BasePath)
 
 	apiGroup.GET(enums.HealthPath, r.healthHandler.HealthChecker)
+	apiGroup.GET(enums.HealthLivePath, r.healthHandler.Liveness)
+	apiGroup.GET(enums.HealthReadyPath, r.healthHandler.Readiness)
+	apiGroup.GET(enums.HealthStartupPath, r.healthHandler.Startup)
+	apiGroup.GET(enums.HealthStreamPath, r.healthHandler.Stream)
+	apiGroup.GET(enums.HealthFleetPath, r.healthHandler.Fleet)
+	apiGroup.GET(enums.HealthFleetServicePath, r.healthHandler.FleetService)
+	apiGroup.GET(enums.HealthFleetComponentPath, r.healthHandler.FleetComponent)
 	apiGroup.GET("/docs/*", echoSwagger.WrapHandler)
+	apiGroup.GET(enums.DashboardPath, r.healthHandler.Dashboard)
 
 	// Endpoints de Beer
 	apiGroup.GET("/beers", r.beerHandler.GetAllBeersHandler)
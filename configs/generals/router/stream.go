@@ -0,0 +1,124 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samuskitchen/go-health-checker/pkg/kit/enums"
+	"github.com/samuskitchen/go-health-checker/pkg/tools/healthcheck"
+
+	"github.com/rs/zerolog/log"
+)
+
+// streamWriteTimeout bounds every write to a stream client, so that a stuck client releases its connection
+const streamWriteTimeout = 10 * time.Second
+
+// Stream sends the health updates as Server-Sent Events
+// @Description Server-Sent Events stream of the health updates. It sends a snapshot on connect, or after the first run
+// @Description of the background polling when there is none yet, or the missed events when Last-Event-ID is still known,
+// @Description then a change event whenever the status or the latency bucket of a check changes.
+// @Tags Health
+// @ID health-stream
+// @Produce text/event-stream
+// @Param Last-Event-ID header string false "ID of the last event received, to resume after a reconnect"
+// @Param verbosity query string false "Use summary to hide the details of each check" Enums(full, summary)
+// @Success 200 {object} healthcheck.Response
+// @Router /health/stream [get]
+func (hh *healthHandler) Stream(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// Before the first run of the background polling there is nothing to replay,
+	// that run is then sent as the snapshot
+	sub, replay := hh.stream.Subscribe(c.Request().Header.Get(enums.HealthLastEventIDHeader))
+	defer hh.stream.Unsubscribe(sub)
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	// Keeps reverse proxies such as nginx from buffering the events
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+
+	writer := streamWriter{res: res, controller: http.NewResponseController(res), verbosity: hh.requestVerbosity(c)}
+	for _, event := range replay {
+		if err := writer.event(event); err != nil {
+			return nil
+		}
+	}
+
+	heartbeat := time.NewTicker(hh.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-sub.Events():
+			if !ok {
+				// Dropped for falling behind, the client resumes from its Last-Event-ID when it reconnects
+				log.Warn().Msgf("Warning: closing a slow health stream client at event %d", writer.lastID)
+				return nil
+			}
+			if err := writer.event(event); err != nil {
+				return nil
+			}
+		case <-heartbeat.C:
+			if err := writer.write(": heartbeat\n\n"); err != nil {
+				return nil
+			}
+		}
+	}
+}
+
+// streamWriter writes the events of the health stream to a client
+type streamWriter struct {
+	res        *echo.Response
+	controller *http.ResponseController
+	verbosity  healthcheck.Verbosity
+	lastID     uint64
+}
+
+// event writes a single event, with the details allowed by the verbosity of the client
+func (sw *streamWriter) event(event healthcheck.StreamEvent) error {
+	data, err := json.Marshal(event.Data.WithVerbosity(sw.verbosity))
+	if err != nil {
+		return err
+	}
+
+	sw.lastID = event.ID
+	return sw.write(fmt.Sprintf("id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data))
+}
+
+// write sends message to the client right away
+func (sw *streamWriter) write(message string) error {
+	// Not every writer supports deadlines, the server write timeout applies then
+	_ = sw.controller.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+
+	if _, err := sw.res.Write([]byte(message)); err != nil {
+		return err
+	}
+
+	return sw.controller.Flush()
+}
+
+// streamHeartbeat reads how often the health stream sends a heartbeat to keep the connections open
+func streamHeartbeat() time.Duration {
+	value := os.Getenv(enums.HealthStreamHeartbeat)
+	if value == "" {
+		return enums.HealthStreamDefaultHeartbeat
+	}
+
+	heartbeat, err := time.ParseDuration(value)
+	if err != nil || heartbeat <= 0 {
+		log.Warn().Msgf("Warning: %s must be a positive duration, using %v", enums.HealthStreamHeartbeat,
+			enums.HealthStreamDefaultHeartbeat)
+		return enums.HealthStreamDefaultHeartbeat
+	}
+
+	return heartbeat
+}
@@ -0,0 +1,95 @@
+package router
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/samuskitchen/go-health-checker/configs/cache"
+	events "github.com/samuskitchen/go-health-checker/configs/event"
+	"github.com/samuskitchen/go-health-checker/configs/health"
+	"github.com/samuskitchen/go-health-checker/configs/storage"
+	"github.com/samuskitchen/go-health-checker/pkg/kit/enums"
+	_mockToolsBroker "github.com/samuskitchen/go-health-checker/pkg/tools/mocks/broker"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// readEvent reads the next event or heartbeat of a stream, without its trailing blank line
+func readEvent(t *testing.T, reader *bufio.Reader) string {
+	var lines []string
+	for {
+		line, err := reader.ReadString('\n')
+		assert.NoError(t, err)
+		if line == "\n" || err != nil {
+			return strings.Join(lines, "")
+		}
+		lines = append(lines, line)
+	}
+}
+
+func TestStream(t *testing.T) {
+	t.Setenv(enums.HealthStreamHeartbeat, "50ms")
+
+	mockBroker := _mockToolsBroker.NewMockClient(t)
+	mockBroker.On("Ping").Return(errors.New("rabbitmq connection is closed")).Once()
+	mockBroker.On("Ping").Return(nil)
+	mockBroker.On("ServerVersion").Return("RabbitMQ 3.13.7", nil)
+
+	checker := health.NewChecker(&storage.Data{}, &cache.Cache{}, &events.RabbitEvent{RabbitMQClient: mockBroker})
+
+	e := echo.New()
+	e.GET(enums.HealthStreamPath, NewHealthHandler(checker).Stream)
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+enums.HealthStreamPath, nil)
+	res, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/event-stream", res.Header.Get(echo.HeaderContentType))
+
+	reader := bufio.NewReader(res.Body)
+	assert.Equal(t, ": heartbeat\n", readEvent(t, reader), "nothing to send before the first run")
+
+	checker.Scheduler.Run(context.Background())
+
+	snapshot := readEvent(t, reader)
+	for strings.HasPrefix(snapshot, ": heartbeat") {
+		snapshot = readEvent(t, reader)
+	}
+	assert.True(t, strings.HasPrefix(snapshot, "id: 1\nevent: snapshot\ndata: {"), snapshot)
+	assert.Contains(t, snapshot, "rabbitmq connection is closed")
+
+	checker.Scheduler.Run(context.Background())
+
+	change := readEvent(t, reader)
+	for strings.HasPrefix(change, ": heartbeat") {
+		change = readEvent(t, reader)
+	}
+	assert.True(t, strings.HasPrefix(change, "id: 2\nevent: change\ndata: {"), change)
+	assert.Contains(t, change, `"overallStatus":"Available"`)
+
+	assert.Equal(t, ": heartbeat\n", readEvent(t, reader))
+
+	t.Run("resume", func(t *testing.T) {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+enums.HealthStreamPath, nil)
+		req.Header.Set(enums.HealthLastEventIDHeader, "1")
+		res, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer res.Body.Close()
+
+		missed := readEvent(t, bufio.NewReader(res.Body))
+		assert.True(t, strings.HasPrefix(missed, "id: 2\nevent: change\n"), missed)
+	})
+}
@@ -0,0 +1,29 @@
+package health
+
+import (
+	"context"
+	"io"
+
+	"github.com/samuskitchen/go-health-checker/configs/cache"
+	events "github.com/samuskitchen/go-health-checker/configs/event"
+	"github.com/samuskitchen/go-health-checker/configs/storage"
+	"github.com/samuskitchen/go-health-checker/pkg/tools/healthcheck"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Check runs the configured checks once, without starting the background polling nor any listener,
+// writes the response to out in format and returns the exit code of the process:
+// 1 when the service is Unavailable, or Degraded when strict, 0 otherwise.
+// The log level of the caller is kept whatever the configuration file sets, so that the output stays clean.
+func Check(clientPg *storage.Data, clientCache *cache.Cache, clientRabbit *events.RabbitEvent,
+	out io.Writer, format healthcheck.Format, strict bool) int {
+	resp := newChecker(clientPg, clientCache, clientRabbit, true).Scheduler.Run(context.Background())
+
+	if err := resp.Write(out, format); err != nil {
+		log.Error().Msgf("Error writing the health check response: %v", err)
+		return 1
+	}
+
+	return resp.ExitCode(strict)
+}
@@ -0,0 +1,45 @@
+package health
+
+import (
+	"net/http"
+	"os"
+
+	"github.com/samuskitchen/go-health-checker/pkg/kit/enums"
+	"github.com/samuskitchen/go-health-checker/pkg/tools/healthcheck"
+
+	"github.com/rs/zerolog/log"
+)
+
+// fleet reads the remote services polled in aggregator mode, nil when there is none
+func fleet() *healthcheck.Aggregator {
+	urls, err := healthcheck.ParseURLs(os.Getenv(enums.HealthFleetServices))
+	if err != nil {
+		log.Warn().Msgf("Warning: %s is invalid, ignoring it: %v", enums.HealthFleetServices, err)
+		return nil
+	}
+
+	if len(urls) == 0 {
+		return nil
+	}
+
+	// Shared by every service so that the connections are reused across runs
+	client := http.DefaultClient
+	if tlsConfig := httpTLSConfig(); tlsConfig != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		client = &http.Client{Transport: transport}
+	}
+
+	services := make(map[string]*healthcheck.RemoteChecker, len(urls))
+	for name, url := range urls {
+		services[name] = &healthcheck.RemoteChecker{URL: url, Client: client}
+	}
+
+	return healthcheck.NewAggregator(healthcheck.AggregatorConfig{
+		Services: services,
+		Critical: healthcheck.ParseNames(os.Getenv(enums.HealthFleetCritical)),
+		Timeout:  durationFromEnv(enums.HealthFleetTimeout, healthcheck.DefaultTimeout, false),
+		Interval: interval(),
+		Deadline: deadline(),
+	})
+}
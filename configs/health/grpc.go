@@ -0,0 +1,55 @@
+package health
+
+import (
+	"net"
+
+	"github.com/samuskitchen/go-health-checker/pkg/kit/enums"
+	"github.com/samuskitchen/go-health-checker/pkg/tools/healthcheck"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// serveGRPC serves the grpc.health.v1.Health service on address with the results of the background polling.
+// It does nothing when address is empty.
+func (c *Checker) serveGRPC(address string) {
+	if address == "" {
+		return
+	}
+
+	if interval() <= 0 {
+		log.Warn().Msgf("Warning: %s is set but background polling is disabled, the gRPC statuses will not change",
+			enums.HealthGRPCAddress)
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		log.Error().Msgf("Error listening for gRPC health checks on %s: %v", address, err)
+		return
+	}
+
+	c.grpcHealth = healthcheck.NewGRPCHealth()
+	c.grpcServer = grpc.NewServer()
+	healthpb.RegisterHealthServer(c.grpcServer, c.grpcHealth.Server())
+	c.Scheduler.AddObserver(c.grpcHealth)
+
+	go func() {
+		if errServe := c.grpcServer.Serve(listener); errServe != nil {
+			log.Error().Msgf("Error serving gRPC health checks: %v", errServe)
+		}
+	}()
+
+	log.Info().Msgf("Serving gRPC health checks on %s", listener.Addr())
+}
+
+// stopGRPC reports every service as NOT_SERVING to the watchers and closes the gRPC health listener.
+// The server is not stopped gracefully because Watch streams never end on their own.
+func (c *Checker) stopGRPC() {
+	if c.grpcServer == nil {
+		return
+	}
+
+	c.grpcHealth.Shutdown()
+	c.grpcServer.Stop()
+}
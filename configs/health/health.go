@@ -0,0 +1,605 @@
+// Package health provides the singleton background health checker
+// that polls the application dependencies and keeps their latest status.
+package health
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samuskitchen/go-health-checker/configs/cache"
+	events "github.com/samuskitchen/go-health-checker/configs/event"
+	"github.com/samuskitchen/go-health-checker/configs/storage"
+	"github.com/samuskitchen/go-health-checker/pkg/kit/enums"
+	"github.com/samuskitchen/go-health-checker/pkg/tools/healthcheck"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+)
+
+var (
+	once    sync.Once
+	checker *Checker
+)
+
+// Checker wraps the scheduler that checks the application dependencies in the background.
+type Checker struct {
+	Scheduler *healthcheck.Scheduler
+	// Fleet polls the remote services in aggregator mode, nil when no remote service is configured
+	Fleet *healthcheck.Aggregator
+	// History remembers the latest results of every component for the status dashboard
+	History *healthcheck.History
+	// Stream sends the health updates to the live subscribers
+	Stream *healthcheck.Stream
+
+	grpcHealth *healthcheck.GRPCHealth
+	grpcServer *grpc.Server
+	notifier   *healthcheck.Notifier
+	publisher  *healthcheck.EventPublisher
+
+	clients    healthcheck.Clients
+	configPath string
+	// logLevel is the level set at startup, restored when the configuration file no longer sets one
+	logLevel zerolog.Level
+	// keepLogLevel ignores the logging level of the configuration file, e.g. to keep the one-shot output clean
+	keepLogLevel bool
+	stopWatch    chan struct{}
+	watchDone    chan struct{}
+}
+
+// HealthChecker returns the singleton Checker instance. The first time it is invoked
+// it registers the checks of the given clients, publishes their results in registry
+// and on the gRPC health listener when it is enabled, notifies the webhooks of their transitions,
+// publishes the transitions to RabbitMQ, starts the background polling of the dependencies
+// and of the remote services in aggregator mode, and reloads the configuration file when it changes.
+func HealthChecker(clientPg *storage.Data, clientCache *cache.Cache, clientRabbit *events.RabbitEvent,
+	registry *prometheus.Registry) *Checker {
+	once.Do(func() {
+		checker = NewChecker(clientPg, clientCache, clientRabbit)
+		checker.Scheduler.AddObserver(healthcheck.NewMetrics(registry))
+		checker.serveGRPC(os.Getenv(enums.HealthGRPCAddress))
+		if hooks := webhooks(); len(hooks) > 0 {
+			checker.notifier = healthcheck.NewNotifier(hooks, healthcheck.NotifierConfig{
+				Retries:  webhookRetries(),
+				Backoff:  durationFromEnv(enums.HealthWebhookBackoff, healthcheck.DefaultWebhookBackoff, false),
+				Instance: instance(),
+			})
+			checker.Scheduler.AddObserver(checker.notifier)
+		}
+		if config, ok := eventConfig(); ok {
+			checker.publisher = healthcheck.NewEventPublisher(clientRabbit.RabbitMQClient, config)
+			checker.Scheduler.AddObserver(checker.publisher)
+		}
+		checker.Scheduler.Start()
+		if checker.Fleet != nil {
+			checker.Fleet.Scheduler.Start()
+		}
+		checker.watchConfig()
+	})
+
+	return checker
+}
+
+// NewChecker builds a Checker for the given clients and the remote services from the environment configuration
+// without starting the background polling.
+func NewChecker(clientPg *storage.Data, clientCache *cache.Cache, clientRabbit *events.RabbitEvent) *Checker {
+	return newChecker(clientPg, clientCache, clientRabbit, false)
+}
+
+// newChecker builds a Checker, keepLogLevel ignores the logging level of the configuration file
+func newChecker(clientPg *storage.Data, clientCache *cache.Cache, clientRabbit *events.RabbitEvent,
+	keepLogLevel bool) *Checker {
+	tlsConfig := httpTLSConfig()
+	clients := healthcheck.Clients{
+		RabbitClient:    clientRabbit.RabbitMQClient,
+		HazelcastClient: clientCache.Hazelcast,
+		RedisClient:     clientCache.Redis,
+		PgClient:        clientPg.DB,
+		Timeouts:        timeouts(),
+		Critical:        criticalChecks(),
+		Weights:         weights(),
+		PostgresDeep:    postgresThresholds(),
+		RabbitQueues:    rabbitQueues(),
+		HazelcastDeep:   hazelcastThresholds(),
+		HTTP:            httpChecks(tlsConfig),
+		HTTPTLSConfig:   tlsConfig,
+		TCP:             tcpChecks(),
+		DNS:             dnsChecks(),
+		Disks:           diskChecks(),
+		Resources:       resourceThresholds(),
+	}
+
+	c := &Checker{
+		Fleet:        fleet(),
+		clients:      clients,
+		configPath:   os.Getenv(enums.HealthConfigFile),
+		logLevel:     zerolog.GlobalLevel(),
+		keepLogLevel: keepLogLevel,
+	}
+	c.Scheduler = healthcheck.NewScheduler(c.checksRegistry(), interval(), deadline())
+	c.History = healthcheck.NewHistory(historySize())
+	c.Scheduler.AddObserver(c.History)
+	c.Stream = healthcheck.NewStream(healthcheck.StreamConfig{Buffer: streamBuffer()})
+	c.Scheduler.AddObserver(c.Stream)
+
+	return c
+}
+
+// HealthCheckerStop stops the configuration reloads, the background polling, the gRPC health listener,
+// the webhook notifications, the event publishing and the polling of the remote services if they have been started.
+func HealthCheckerStop() {
+	if checker != nil {
+		checker.stopWatchingConfig()
+		checker.stopGRPC()
+		checker.Scheduler.Stop()
+		if checker.notifier != nil {
+			checker.notifier.Close()
+		}
+		if checker.publisher != nil {
+			checker.publisher.Close()
+		}
+		if checker.Fleet != nil {
+			checker.Fleet.Scheduler.Stop()
+		}
+	}
+}
+
+// checksRegistry builds the checks declared in the HealthConfigFile when it is set, those of the clients otherwise.
+// A bad configuration file stops the service, so that it never runs with checks missing.
+func (c *Checker) checksRegistry() *healthcheck.Registry {
+	if c.configPath == "" {
+		return c.clients.Registry()
+	}
+
+	registry, err := c.loadConfig()
+	if err != nil {
+		log.Fatal().Msgf("Error loading %s %s: %v", enums.HealthConfigFile, c.configPath, err)
+	}
+
+	return registry
+}
+
+// timeouts reads the per check timeouts, ignoring them when they are malformed
+func timeouts() map[string]time.Duration {
+	values, err := healthcheck.ParseTimeouts(os.Getenv(enums.HealthCheckTimeouts))
+	if err != nil {
+		log.Warn().Msgf("Warning: %s is invalid, using default timeouts: %v", enums.HealthCheckTimeouts, err)
+		return nil
+	}
+
+	return values
+}
+
+// criticalChecks reads the names of the checks the service cannot work without
+func criticalChecks() map[string]bool {
+	value, ok := os.LookupEnv(enums.HealthCriticalChecks)
+	if !ok {
+		value = enums.HealthDefaultCriticalChecks
+	}
+
+	return healthcheck.ParseNames(value)
+}
+
+// weights reads the per check weights, ignoring them when they are malformed
+func weights() map[string]float64 {
+	values, err := healthcheck.ParseWeights(os.Getenv(enums.HealthCheckWeights))
+	if err != nil {
+		log.Warn().Msgf("Warning: %s is invalid, using default weights: %v", enums.HealthCheckWeights, err)
+		return nil
+	}
+
+	return values
+}
+
+// postgresThresholds reads the configuration of the deep PostgreSQL check, nil when it is disabled
+func postgresThresholds() *healthcheck.PostgresThresholds {
+	if !boolFromEnv(enums.HealthPostgresDeep, false) {
+		return nil
+	}
+
+	thresholds := &healthcheck.PostgresThresholds{
+		ProbeQuery:        os.Getenv(enums.HealthPostgresProbeQuery),
+		MaxReplicationLag: durationFromEnv(enums.HealthPostgresMaxReplicationLag, 0, true),
+		DegradeOnReadOnly: boolFromEnv(enums.HealthPostgresDegradeOnReadOnly, true),
+	}
+
+	if value := os.Getenv(enums.HealthPostgresMaxWaitCount); value != "" {
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || count < 0 {
+			log.Warn().Msgf("Warning: %s must be a positive number, ignoring it", enums.HealthPostgresMaxWaitCount)
+		} else {
+			thresholds.MaxWaitCount = count
+		}
+	}
+
+	if value := os.Getenv(enums.HealthPostgresMaxInUseRatio); value != "" {
+		ratio, err := strconv.ParseFloat(value, 64)
+		if err != nil || ratio < 0 || ratio > 1 {
+			log.Warn().Msgf("Warning: %s must be between 0 and 1, ignoring it", enums.HealthPostgresMaxInUseRatio)
+		} else {
+			thresholds.MaxInUseRatio = ratio
+		}
+	}
+
+	return thresholds
+}
+
+// rabbitQueues reads the queues inspected by the RabbitMQ check and their thresholds,
+// ignoring the thresholds when they are malformed
+func rabbitQueues() []healthcheck.QueueThresholds {
+	names := healthcheck.ParseNames(os.Getenv(enums.HealthRabbitQueues))
+	if len(names) == 0 {
+		return nil
+	}
+
+	degraded := countsFromEnv(enums.HealthRabbitDegradedMessages)
+	unhealthy := countsFromEnv(enums.HealthRabbitUnhealthyMessages)
+	noConsumersAllowed := healthcheck.ParseNames(os.Getenv(enums.HealthRabbitNoConsumersAllowed))
+
+	queues := make([]healthcheck.QueueThresholds, 0, len(names))
+	for _, name := range slices.Sorted(maps.Keys(names)) {
+		queues = append(queues, healthcheck.QueueThresholds{
+			Name:              name,
+			DegradedMessages:  degraded[name],
+			UnhealthyMessages: unhealthy[name],
+			AllowNoConsumers:  noConsumersAllowed[name],
+		})
+	}
+
+	return queues
+}
+
+// hazelcastThresholds reads the configuration of the deep Hazelcast check, nil when it is disabled
+func hazelcastThresholds() *healthcheck.HazelcastThresholds {
+	if !boolFromEnv(enums.HealthHazelcastDeep, false) {
+		return nil
+	}
+
+	thresholds := &healthcheck.HazelcastThresholds{
+		MapName:      os.Getenv(enums.HealthHazelcastMap),
+		TTL:          durationFromEnv(enums.HealthHazelcastTTL, healthcheck.DefaultHazelcastHealthTTL, false),
+		MaxRoundTrip: durationFromEnv(enums.HealthHazelcastMaxRoundTrip, 0, true),
+	}
+
+	if value := os.Getenv(enums.HealthHazelcastMinMembers); value != "" {
+		members, err := strconv.Atoi(value)
+		if err != nil || members < 0 {
+			log.Warn().Msgf("Warning: %s must be a positive number, ignoring it", enums.HealthHazelcastMinMembers)
+		} else {
+			thresholds.MinMembers = members
+		}
+	}
+
+	return thresholds
+}
+
+// httpChecks reads the upstream HTTP dependencies, ignoring them when they are malformed
+func httpChecks(tlsConfig *tls.Config) map[string]*healthcheck.HTTPChecker {
+	urls, err := healthcheck.ParseURLs(os.Getenv(enums.HealthHTTPChecks))
+	if err != nil {
+		log.Warn().Msgf("Warning: %s is invalid, ignoring it: %v", enums.HealthHTTPChecks, err)
+		return nil
+	}
+
+	checks := make(map[string]*healthcheck.HTTPChecker, len(urls))
+	for name, url := range urls {
+		checks[name] = &healthcheck.HTTPChecker{URL: url, TLSConfig: tlsConfig}
+	}
+
+	return checks
+}
+
+// tcpChecks reads the TCP endpoints to dial and their banners, ignoring them when they are malformed
+func tcpChecks() map[string]*healthcheck.TCPChecker {
+	addresses, err := healthcheck.ParseAddresses(os.Getenv(enums.HealthTCPChecks))
+	if err != nil {
+		log.Warn().Msgf("Warning: %s is invalid, ignoring it: %v", enums.HealthTCPChecks, err)
+		return nil
+	}
+
+	banners, err := healthcheck.ParseStrings(os.Getenv(enums.HealthTCPBanners))
+	if err != nil {
+		log.Warn().Msgf("Warning: %s is invalid, ignoring it: %v", enums.HealthTCPBanners, err)
+	}
+
+	checks := make(map[string]*healthcheck.TCPChecker, len(addresses))
+	for name, address := range addresses {
+		checks[name] = &healthcheck.TCPChecker{Address: address, Banner: banners[name]}
+	}
+
+	return checks
+}
+
+// dnsChecks reads the hostnames to resolve and their minimum records, ignoring them when they are malformed
+func dnsChecks() map[string]*healthcheck.DNSChecker {
+	hosts, err := healthcheck.ParseStrings(os.Getenv(enums.HealthDNSChecks))
+	if err != nil {
+		log.Warn().Msgf("Warning: %s is invalid, ignoring it: %v", enums.HealthDNSChecks, err)
+		return nil
+	}
+
+	minRecords := countsFromEnv(enums.HealthDNSMinRecords)
+	server := os.Getenv(enums.HealthDNSServer)
+
+	checks := make(map[string]*healthcheck.DNSChecker, len(hosts))
+	for name, host := range hosts {
+		checks[name] = &healthcheck.DNSChecker{Host: host, MinRecords: minRecords[name], Server: server}
+	}
+
+	return checks
+}
+
+// diskChecks reads the filesystems whose free space is checked, ignoring them when they are malformed
+func diskChecks() map[string]*healthcheck.DiskChecker {
+	paths, err := healthcheck.ParseStrings(os.Getenv(enums.HealthDiskPaths))
+	if err != nil {
+		log.Warn().Msgf("Warning: %s is invalid, ignoring it: %v", enums.HealthDiskPaths, err)
+		return nil
+	}
+
+	usage := thresholdsFromEnv(enums.HealthDiskWarnPercent, enums.HealthDiskCriticalPercent,
+		healthcheck.DefaultDiskUsage)
+
+	checks := make(map[string]*healthcheck.DiskChecker, len(paths))
+	for name, path := range paths {
+		checks[name] = &healthcheck.DiskChecker{Path: path, Usage: usage}
+	}
+
+	return checks
+}
+
+// resourceThresholds reads the configuration of the checks of the process resources, nil when they are disabled
+func resourceThresholds() *healthcheck.ResourceThresholds {
+	if !boolFromEnv(enums.HealthResources, false) {
+		return nil
+	}
+
+	return &healthcheck.ResourceThresholds{
+		RSS:  thresholdsFromEnv(enums.HealthRSSWarnMB, enums.HealthRSSCriticalMB, healthcheck.Thresholds{}),
+		Heap: thresholdsFromEnv(enums.HealthHeapWarnMB, enums.HealthHeapCriticalMB, healthcheck.Thresholds{}),
+		FileDescriptors: thresholdsFromEnv(enums.HealthFDWarnPercent, enums.HealthFDCriticalPercent,
+			healthcheck.DefaultFileDescriptorUsage),
+		Goroutines: thresholdsFromEnv(enums.HealthGoroutinesWarn, enums.HealthGoroutinesCritical,
+			healthcheck.DefaultGoroutines),
+	}
+}
+
+// webhooks reads the endpoints notified of the status transitions with their secrets and filters,
+// ignoring them when they are malformed
+func webhooks() []healthcheck.Webhook {
+	urls, err := healthcheck.ParseURLs(os.Getenv(enums.HealthWebhooks))
+	if err != nil {
+		log.Warn().Msgf("Warning: %s is invalid, ignoring it: %v", enums.HealthWebhooks, err)
+		return nil
+	}
+
+	secrets := stringsFromEnv(enums.HealthWebhookSecrets)
+	components := stringsFromEnv(enums.HealthWebhookComponents)
+	severities := stringsFromEnv(enums.HealthWebhookSeverities)
+
+	hooks := make([]healthcheck.Webhook, 0, len(urls))
+	for _, name := range slices.Sorted(maps.Keys(urls)) {
+		hook := healthcheck.Webhook{URL: urls[name], Secret: secrets[name]}
+
+		if value := components[name]; value != "" {
+			hook.Components = healthcheck.ParseNames(strings.ReplaceAll(value, "|", ","))
+		}
+
+		if value := severities[name]; value != "" {
+			if hook.MinSeverity, err = healthcheck.ParseSeverity(value); err != nil {
+				log.Warn().Msgf("Warning: %s of %s is invalid, notifying every transition: %v",
+					enums.HealthWebhookSeverities, name, err)
+			}
+		}
+
+		hooks = append(hooks, hook)
+	}
+
+	return hooks
+}
+
+// historySize reads the number of runs remembered per component, healthcheck.DefaultHistorySize when not set
+func historySize() int {
+	value := os.Getenv(enums.HealthDashboardHistory)
+	if value == "" {
+		return healthcheck.DefaultHistorySize
+	}
+
+	size, err := strconv.Atoi(value)
+	if err != nil || size <= 0 {
+		log.Warn().Msgf("Warning: %s must be a positive number, using %d", enums.HealthDashboardHistory,
+			healthcheck.DefaultHistorySize)
+		return healthcheck.DefaultHistorySize
+	}
+
+	return size
+}
+
+// streamBuffer reads the number of events queued per stream subscriber, the default when not set
+func streamBuffer() int {
+	value := os.Getenv(enums.HealthStreamBuffer)
+	if value == "" {
+		return healthcheck.DefaultStreamBuffer
+	}
+
+	buffer, err := strconv.Atoi(value)
+	if err != nil || buffer <= 0 {
+		log.Warn().Msgf("Warning: %s must be a positive number, using %d", enums.HealthStreamBuffer,
+			healthcheck.DefaultStreamBuffer)
+		return healthcheck.DefaultStreamBuffer
+	}
+
+	return buffer
+}
+
+// webhookRetries reads the number of retries of a failed webhook delivery
+func webhookRetries() int {
+	value := os.Getenv(enums.HealthWebhookRetries)
+	if value == "" {
+		return healthcheck.DefaultWebhookRetries
+	}
+
+	retries, err := strconv.Atoi(value)
+	if err != nil || retries <= 0 {
+		log.Warn().Msgf("Warning: %s must be a positive number, using %d", enums.HealthWebhookRetries,
+			healthcheck.DefaultWebhookRetries)
+		return healthcheck.DefaultWebhookRetries
+	}
+
+	return retries
+}
+
+// eventConfig reads where the transitions are published, reporting whether the publishing is enabled
+func eventConfig() (healthcheck.EventConfig, bool) {
+	config := healthcheck.EventConfig{
+		Exchange:   os.Getenv(enums.HealthEventsExchange),
+		RoutingKey: os.Getenv(enums.HealthEventsRoutingKey),
+	}
+
+	if config.Exchange == "" && config.RoutingKey == "" {
+		return config, false
+	}
+
+	config.Instance = instance()
+	return config, true
+}
+
+// instance reads the identifier of this instance in the transitions, the hostname when it is not set
+func instance() string {
+	if value := os.Getenv(enums.HealthInstanceID); value != "" {
+		return value
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		log.Warn().Msgf("Warning: the hostname is unknown, set %s to identify this instance: %v",
+			enums.HealthInstanceID, err)
+		return ""
+	}
+
+	return hostname
+}
+
+// httpTLSConfig trusts the CAs of HealthHTTPCAFile besides the system ones, nil when it is not set
+func httpTLSConfig() *tls.Config {
+	file := os.Getenv(enums.HealthHTTPCAFile)
+	if file == "" {
+		return nil
+	}
+
+	pem, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		log.Warn().Msgf("Warning: %s could not be read, using the system CAs: %v", enums.HealthHTTPCAFile, err)
+		return nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		log.Warn().Msgf("Warning: %s holds no PEM certificate, using the system CAs", enums.HealthHTTPCAFile)
+		return nil
+	}
+
+	return &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+}
+
+// stringsFromEnv reads per name values from the environment, ignoring them when they are malformed
+func stringsFromEnv(key string) map[string]string {
+	values, err := healthcheck.ParseStrings(os.Getenv(key))
+	if err != nil {
+		log.Warn().Msgf("Warning: %s is invalid, ignoring it: %v", key, err)
+		return nil
+	}
+
+	return values
+}
+
+// countsFromEnv reads per queue counts from the environment, ignoring them when they are malformed
+func countsFromEnv(key string) map[string]int {
+	values, err := healthcheck.ParseCounts(os.Getenv(key))
+	if err != nil {
+		log.Warn().Msgf("Warning: %s is invalid, ignoring it: %v", key, err)
+		return nil
+	}
+
+	return values
+}
+
+// thresholdsFromEnv reads warning and critical thresholds from the environment,
+// falling back to the ones of defaultValue that are unset or invalid
+func thresholdsFromEnv(warnKey, criticalKey string, defaultValue healthcheck.Thresholds) healthcheck.Thresholds {
+	return healthcheck.Thresholds{
+		Warn:     floatFromEnv(warnKey, defaultValue.Warn),
+		Critical: floatFromEnv(criticalKey, defaultValue.Critical),
+	}
+}
+
+// floatFromEnv reads a positive number from the environment, falling back to defaultValue when unset or invalid
+func floatFromEnv(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil || parsed < 0 {
+		log.Warn().Msgf("Warning: %s must be a positive number, using %v", key, defaultValue)
+		return defaultValue
+	}
+
+	return parsed
+}
+
+// boolFromEnv reads a boolean from the environment, falling back to defaultValue when unset or invalid
+func boolFromEnv(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Warn().Msgf("Warning: %s must be set to true or false, using %v", key, defaultValue)
+		return defaultValue
+	}
+
+	return parsed
+}
+
+// deadline reads the deadline of a whole health check run
+func deadline() time.Duration {
+	return durationFromEnv(enums.HealthCheckDeadline, enums.HealthCheckDefaultDeadline, false)
+}
+
+// interval reads the background polling interval, zero disables polling
+func interval() time.Duration {
+	return durationFromEnv(enums.HealthCheckInterval, enums.HealthCheckDefaultInterval, true)
+}
+
+// durationFromEnv reads a duration from the environment, falling back to defaultValue when unset or invalid
+func durationFromEnv(key string, defaultValue time.Duration, allowZero bool) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 || (duration == 0 && !allowZero) {
+		log.Warn().Msgf("Warning: %s must be a positive duration, using %v", key, defaultValue)
+		return defaultValue
+	}
+
+	return duration
+}
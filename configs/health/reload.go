@@ -0,0 +1,137 @@
+package health
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/samuskitchen/go-health-checker/pkg/kit/enums"
+	"github.com/samuskitchen/go-health-checker/pkg/tools/healthcheck"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// fileVersion tells two versions of the configuration file apart
+type fileVersion struct {
+	modTime time.Time
+	size    int64
+}
+
+// loadConfig builds the checks declared in the configuration file and applies its logging settings,
+// going back to the startup level when the file sets none. Nothing is applied when the file is invalid.
+func (c *Checker) loadConfig() (*healthcheck.Registry, error) {
+	config, err := healthcheck.LoadFileConfig(c.configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	registry, err := config.Registry(&c.clients)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.keepLogLevel {
+		level := c.logLevel
+		if config.Logging.Level != "" {
+			// Already validated by LoadFileConfig
+			level, _ = zerolog.ParseLevel(config.Logging.Level)
+		}
+		zerolog.SetGlobalLevel(level)
+	}
+
+	return registry, nil
+}
+
+// watchConfig reloads the configuration file when it changes on disk or the process receives SIGHUP.
+// It does nothing when no configuration file is set.
+func (c *Checker) watchConfig() {
+	if c.configPath == "" {
+		return
+	}
+
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+
+	var ticker *time.Ticker
+	every := durationFromEnv(enums.HealthConfigWatchInterval, enums.HealthConfigDefaultWatchInterval, true)
+	if every > 0 {
+		ticker = time.NewTicker(every)
+	}
+
+	c.stopWatch = make(chan struct{})
+	c.watchDone = make(chan struct{})
+	go c.watch(hangup, ticker)
+
+	log.Info().Msgf("Watching %s for changes, send SIGHUP to reload it", c.configPath)
+}
+
+// watch reloads the configuration on every hangup and on every tick that finds the file changed,
+// until stopWatchingConfig is called
+func (c *Checker) watch(hangup chan os.Signal, ticker *time.Ticker) {
+	defer close(c.watchDone)
+	defer signal.Stop(hangup)
+
+	var tick <-chan time.Time
+	if ticker != nil {
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	last, _ := c.configVersion()
+	for {
+		select {
+		case <-c.stopWatch:
+			return
+		case <-hangup:
+			last, _ = c.configVersion()
+			c.reloadConfig("SIGHUP")
+		case <-tick:
+			// A file being replaced may be missing for a moment, it is reloaded once it is back
+			if version, err := c.configVersion(); err == nil && version != last {
+				last = version
+				c.reloadConfig("a change")
+			}
+		}
+	}
+}
+
+// reloadConfig swaps the checks for those of the configuration file, keeping the current ones when it is invalid.
+// The requests in flight finish with the checks they started with.
+func (c *Checker) reloadConfig(reason string) {
+	registry, err := c.loadConfig()
+	if err != nil {
+		log.Error().Msgf("Error reloading %s %s after %s, keeping the previous configuration: %v",
+			enums.HealthConfigFile, c.configPath, reason, err)
+		return
+	}
+
+	c.Scheduler.SetRegistry(registry)
+	log.Info().Msgf("Reloaded %s %s after %s with %d checks", enums.HealthConfigFile, c.configPath, reason,
+		len(registry.Checks()))
+
+	// Refreshes the snapshot right away instead of serving the previous checks until the next interval
+	c.Scheduler.Refresh(context.Background())
+}
+
+// configVersion returns the version of the configuration file on disk
+func (c *Checker) configVersion() (fileVersion, error) {
+	info, err := os.Stat(c.configPath)
+	if err != nil {
+		return fileVersion{}, err
+	}
+
+	return fileVersion{modTime: info.ModTime(), size: info.Size()}, nil
+}
+
+// stopWatchingConfig stops the configuration reloads if they have been started
+func (c *Checker) stopWatchingConfig() {
+	if c.stopWatch == nil {
+		return
+	}
+
+	close(c.stopWatch)
+	<-c.watchDone
+}
@@ -0,0 +1,45 @@
+// Package metrics provides the singleton Prometheus registry of the application,
+// holding the Go runtime, process and PostgreSQL pool metrics.
+package metrics
+
+import (
+	"os"
+	"sync"
+
+	"github.com/samuskitchen/go-health-checker/configs/storage"
+	"github.com/samuskitchen/go-health-checker/pkg/kit/enums"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+var (
+	once     sync.Once
+	registry *prometheus.Registry
+)
+
+// Registry returns the singleton Prometheus registry.
+// Initializes it the first time it is invoked.
+func Registry(clientPg *storage.Data) *prometheus.Registry {
+	once.Do(func() {
+		registry = NewRegistry(clientPg)
+	})
+
+	return registry
+}
+
+// NewRegistry builds a registry with the Go runtime and process metrics,
+// plus the sql.DBStats of the PostgreSQL pool when it is connected.
+func NewRegistry(clientPg *storage.Data) *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	if clientPg.DB != nil {
+		reg.MustRegister(collectors.NewDBStatsCollector(clientPg.DB, os.Getenv(enums.PostgresDatabase)))
+	}
+
+	return reg
+}
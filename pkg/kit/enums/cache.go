@@ -1,5 +1,5 @@
 // Package enums define environment configuration constants
-// and general values used for Hazelcast.
+// and general values used for Hazelcast and Redis.
 package enums
 
 import "time"
@@ -15,3 +15,29 @@ const (
 	// CacheGeneralTTL defines the time to live (24h) for the general cache.
 	CacheGeneralTTL time.Duration = 24 * time.Hour
 )
+
+// Environment variables to select the cache provider.
+const (
+	// CacheProvider selects the cache provider, CacheProviderHazelcast by default.
+	CacheProvider string = "CACHE_PROVIDER"
+	// CacheProviderHazelcast selects Hazelcast as the cache provider.
+	CacheProviderHazelcast string = "hazelcast"
+	// CacheProviderRedis selects Redis as the cache provider.
+	CacheProviderRedis string = "redis"
+)
+
+// Environment variables to configure the connection to Redis.
+const (
+	// RedisServer specifies the address (host:port) of the Redis server.
+	RedisServer string = "REDIS_SERVER"
+	// RedisUsername specifies the ACL user of the Redis connection, the default user when empty.
+	RedisUsername string = "REDIS_USERNAME"
+	// RedisPassword specifies the password of the Redis connection.
+	RedisPassword string = "REDIS_PASSWORD"
+	// RedisDB specifies the Redis database to select, 0 by default.
+	RedisDB string = "REDIS_DB"
+	// RedisTLS enables TLS on the Redis connection when set to true.
+	RedisTLS string = "REDIS_TLS"
+	// RedisClientName specifies the name the Redis client will use.
+	RedisClientName string = "health-checker-client"
+)
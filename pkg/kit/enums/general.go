@@ -8,6 +8,39 @@ const (
 	// HealthPath is the path to the health check endpoint.
 	HealthPath string = "/health"
 
+	// HealthLivePath is the path to the liveness probe endpoint.
+	HealthLivePath string = HealthPath + "/live"
+
+	// HealthReadyPath is the path to the readiness probe endpoint.
+	HealthReadyPath string = HealthPath + "/ready"
+
+	// HealthStartupPath is the path to the startup probe endpoint.
+	HealthStartupPath string = HealthPath + "/startup"
+
+	// HealthStreamPath is the path to the Server-Sent Events stream of the health updates.
+	HealthStreamPath string = HealthPath + "/stream"
+
+	// HealthFleetPath is the path to the combined health of the remote services in aggregator mode.
+	HealthFleetPath string = HealthPath + "/fleet"
+
+	// HealthFleetServicePath is the path to the health of a single remote service.
+	HealthFleetServicePath string = HealthFleetPath + "/:" + HealthFleetServiceParam
+
+	// HealthFleetComponentPath is the path to the health of a single component of a remote service.
+	HealthFleetComponentPath string = HealthFleetServicePath + "/:" + HealthFleetComponentParam
+
+	// HealthFleetServiceParam is the path parameter holding the name of a remote service.
+	HealthFleetServiceParam string = "service"
+
+	// HealthFleetComponentParam is the path parameter holding the name of a component of a remote service.
+	HealthFleetComponentParam string = "component"
+
+	// DashboardPath is the path to the HTML status dashboard.
+	DashboardPath string = "/status"
+
+	// MetricsPath is the path to the Prometheus metrics endpoint, served outside BasePath.
+	MetricsPath string = "/metrics"
+
 	// ServerHost is the config key for the server hostname.
 	ServerHost string = "SERVER_HOST"
 
@@ -25,4 +58,7 @@ const (
 
 	// App is the application name used in logs and metrics.
 	App string = "go-health-checker"
+
+	// CheckCommand is the subcommand that runs the health checks once instead of starting the server.
+	CheckCommand string = "check"
 )
@@ -0,0 +1,145 @@
+// Package enums contains configuration constants for the health check endpoints.
+package enums
+
+import "time"
+
+const (
+	// HealthConfigFile is the configuration key for the YAML or JSON file declaring the checks instead of the variables.
+	HealthConfigFile string = "HEALTH_CONFIG_FILE"
+	// HealthConfigWatchInterval is the configuration key for how often HealthConfigFile is polled for changes.
+	HealthConfigWatchInterval string = "HEALTH_CONFIG_WATCH_INTERVAL"
+	// HealthConfigDefaultWatchInterval is the polling interval applied when HealthConfigWatchInterval is not set.
+	HealthConfigDefaultWatchInterval time.Duration = 5 * time.Second
+	// HealthCheckTimeouts is the configuration key for the per check timeouts, e.g. "postgresql-sql-connection=2s".
+	HealthCheckTimeouts string = "HEALTH_CHECK_TIMEOUTS"
+	// HealthCheckDeadline is the configuration key for the deadline of a whole health check run.
+	HealthCheckDeadline string = "HEALTH_CHECK_DEADLINE"
+	// HealthCheckDefaultDeadline is the deadline applied when HealthCheckDeadline is not set.
+	HealthCheckDefaultDeadline time.Duration = 8 * time.Second
+	// HealthCheckInterval is the configuration key for the background polling interval, "0s" disables polling.
+	HealthCheckInterval string = "HEALTH_CHECK_INTERVAL"
+	// HealthCheckDefaultInterval is the polling interval applied when HealthCheckInterval is not set.
+	HealthCheckDefaultInterval time.Duration = 15 * time.Second
+	// HealthDashboardRefresh is the configuration key for how often the status dashboard reloads, "0s" disables it.
+	HealthDashboardRefresh string = "HEALTH_DASHBOARD_REFRESH"
+	// HealthDashboardDefaultRefresh is the reload interval applied when HealthDashboardRefresh is not set.
+	HealthDashboardDefaultRefresh time.Duration = 15 * time.Second
+	// HealthDashboardHistory is the configuration key for the number of runs shown per component on the dashboard.
+	HealthDashboardHistory string = "HEALTH_DASHBOARD_HISTORY"
+	// HealthStreamHeartbeat is the configuration key for how often the health stream sends a heartbeat.
+	HealthStreamHeartbeat string = "HEALTH_STREAM_HEARTBEAT"
+	// HealthStreamDefaultHeartbeat is the heartbeat interval applied when HealthStreamHeartbeat is not set.
+	HealthStreamDefaultHeartbeat time.Duration = 15 * time.Second
+	// HealthStreamBuffer is the configuration key for the number of events queued per health stream client.
+	HealthStreamBuffer string = "HEALTH_STREAM_BUFFER"
+	// HealthLastEventIDHeader is the header a health stream client sends back to resume after a reconnect.
+	HealthLastEventIDHeader string = "Last-Event-ID"
+	// HealthFreshParam is the query parameter that forces a live run instead of serving the latest snapshot.
+	HealthFreshParam string = "fresh"
+	// HealthVerbosity is the configuration key for the detail of the health responses, "full" or "summary".
+	HealthVerbosity string = "HEALTH_VERBOSITY"
+	// HealthVerbosityParam is the query parameter that lets a caller ask for a "summary" response.
+	HealthVerbosityParam string = "verbosity"
+	// HealthCriticalChecks is the configuration key for the comma separated names of the critical checks.
+	HealthCriticalChecks string = "HEALTH_CRITICAL_CHECKS"
+	// HealthDefaultCriticalChecks are the critical checks used when HealthCriticalChecks is not set.
+	HealthDefaultCriticalChecks string = "postgresql-sql-connection"
+	// HealthCheckWeights is the configuration key for the per check weights, e.g. "hazelcast-connection=0.5".
+	HealthCheckWeights string = "HEALTH_CHECK_WEIGHTS"
+	// HealthDegradedStatusCode is the configuration key for the HTTP status code returned while degraded (200 or 207).
+	HealthDegradedStatusCode string = "HEALTH_DEGRADED_STATUS_CODE"
+	// HealthPostgresDeep is the configuration key that enables the deep PostgreSQL check, "true" or "false".
+	HealthPostgresDeep string = "HEALTH_POSTGRES_DEEP"
+	// HealthPostgresProbeQuery is the configuration key for the query run by the deep PostgreSQL check.
+	HealthPostgresProbeQuery string = "HEALTH_POSTGRES_PROBE_QUERY"
+	// HealthPostgresMaxWaitCount is the configuration key for the pool waits between two checks that degrade it.
+	HealthPostgresMaxWaitCount string = "HEALTH_POSTGRES_MAX_WAIT_COUNT"
+	// HealthPostgresMaxInUseRatio is the configuration key for the share of connections in use that degrades it.
+	HealthPostgresMaxInUseRatio string = "HEALTH_POSTGRES_MAX_IN_USE_RATIO"
+	// HealthPostgresMaxReplicationLag is the configuration key for the replication lag that degrades it.
+	HealthPostgresMaxReplicationLag string = "HEALTH_POSTGRES_MAX_REPLICATION_LAG"
+	// HealthPostgresDegradeOnReadOnly is the configuration key that degrades it on a read-only server, "true" by default.
+	HealthPostgresDegradeOnReadOnly string = "HEALTH_POSTGRES_DEGRADE_ON_READ_ONLY"
+	// HealthRabbitQueues is the configuration key for the comma separated queues inspected by the RabbitMQ check.
+	HealthRabbitQueues string = "HEALTH_RABBITMQ_QUEUES"
+	// HealthRabbitDegradedMessages is the configuration key for the per queue messages that degrade it, e.g. "beers=100".
+	HealthRabbitDegradedMessages string = "HEALTH_RABBITMQ_DEGRADED_MESSAGES"
+	// HealthRabbitUnhealthyMessages is the configuration key for the per queue messages that fail it, e.g. "beers=1000".
+	HealthRabbitUnhealthyMessages string = "HEALTH_RABBITMQ_UNHEALTHY_MESSAGES"
+	// HealthRabbitNoConsumersAllowed is the configuration key for the comma separated queues that may have no consumers.
+	HealthRabbitNoConsumersAllowed string = "HEALTH_RABBITMQ_NO_CONSUMERS_ALLOWED"
+	// HealthHazelcastDeep is the configuration key that enables the deep Hazelcast check, "true" or "false".
+	HealthHazelcastDeep string = "HEALTH_HAZELCAST_DEEP"
+	// HealthHazelcastMap is the configuration key for the dedicated map of the Hazelcast round trip.
+	HealthHazelcastMap string = "HEALTH_HAZELCAST_MAP"
+	// HealthHazelcastTTL is the configuration key for the TTL of the Hazelcast round trip entry.
+	HealthHazelcastTTL string = "HEALTH_HAZELCAST_TTL"
+	// HealthHazelcastMaxRoundTrip is the configuration key for the round trip latency that degrades it.
+	HealthHazelcastMaxRoundTrip string = "HEALTH_HAZELCAST_MAX_ROUND_TRIP"
+	// HealthHazelcastMinMembers is the configuration key for the cluster members below which it fails.
+	HealthHazelcastMinMembers string = "HEALTH_HAZELCAST_MIN_MEMBERS"
+	// HealthHTTPChecks is the configuration key for the upstream HTTP dependencies, e.g. "catalog=https://catalog/health".
+	HealthHTTPChecks string = "HEALTH_HTTP_CHECKS"
+	// HealthHTTPCAFile is the configuration key for a PEM file of extra CAs trusted by the HTTP checks.
+	HealthHTTPCAFile string = "HEALTH_HTTP_CA_FILE"
+	// HealthTCPChecks is the configuration key for the TCP endpoints to dial, e.g. "smtp-relay=smtp.internal:25".
+	HealthTCPChecks string = "HEALTH_TCP_CHECKS"
+	// HealthTCPBanners is the configuration key for the banners expected from the TCP endpoints, e.g. "smtp-relay=220".
+	HealthTCPBanners string = "HEALTH_TCP_BANNERS"
+	// HealthDNSChecks is the configuration key for the hostnames to resolve, e.g. "catalog-dns=catalog.internal".
+	HealthDNSChecks string = "HEALTH_DNS_CHECKS"
+	// HealthDNSMinRecords is the configuration key for the records each hostname needs, e.g. "catalog-dns=2".
+	HealthDNSMinRecords string = "HEALTH_DNS_MIN_RECORDS"
+	// HealthDNSServer is the configuration key for the host:port of the DNS server, the system resolver when unset.
+	HealthDNSServer string = "HEALTH_DNS_SERVER"
+	// HealthDiskPaths is the configuration key for the filesystems whose free space is checked, e.g. "data=/var/lib/data".
+	HealthDiskPaths string = "HEALTH_DISK_PATHS"
+	// HealthDiskWarnPercent is the configuration key for the share of a disk in use that degrades its check.
+	HealthDiskWarnPercent string = "HEALTH_DISK_WARN_PERCENT"
+	// HealthDiskCriticalPercent is the configuration key for the share of a disk in use that fails its check.
+	HealthDiskCriticalPercent string = "HEALTH_DISK_CRITICAL_PERCENT"
+	// HealthResources is the configuration key to enable the memory, file descriptors and goroutines checks.
+	HealthResources string = "HEALTH_RESOURCES"
+	// HealthRSSWarnMB is the configuration key for the resident memory, in MB, that degrades the memory check.
+	HealthRSSWarnMB string = "HEALTH_RSS_WARN_MB"
+	// HealthRSSCriticalMB is the configuration key for the resident memory, in MB, that fails the memory check.
+	HealthRSSCriticalMB string = "HEALTH_RSS_CRITICAL_MB"
+	// HealthHeapWarnMB is the configuration key for the Go heap, in MB, that degrades the memory check.
+	HealthHeapWarnMB string = "HEALTH_HEAP_WARN_MB"
+	// HealthHeapCriticalMB is the configuration key for the Go heap, in MB, that fails the memory check.
+	HealthHeapCriticalMB string = "HEALTH_HEAP_CRITICAL_MB"
+	// HealthFDWarnPercent is the configuration key for the share of the open files limit in use that degrades its check.
+	HealthFDWarnPercent string = "HEALTH_FD_WARN_PERCENT"
+	// HealthFDCriticalPercent is the configuration key for the share of the open files limit in use that fails its check.
+	HealthFDCriticalPercent string = "HEALTH_FD_CRITICAL_PERCENT"
+	// HealthGoroutinesWarn is the configuration key for the number of goroutines that degrades their check.
+	HealthGoroutinesWarn string = "HEALTH_GOROUTINES_WARN"
+	// HealthGoroutinesCritical is the configuration key for the number of goroutines that fails their check.
+	HealthGoroutinesCritical string = "HEALTH_GOROUTINES_CRITICAL"
+	// HealthGRPCAddress is the configuration key for the address of the gRPC health listener, e.g. ":9090".
+	HealthGRPCAddress string = "HEALTH_GRPC_ADDRESS"
+	// HealthWebhooks is the configuration key for the endpoints notified of status changes, e.g. "ops=https://ops/hook".
+	HealthWebhooks string = "HEALTH_WEBHOOKS"
+	// HealthWebhookSecrets is the configuration key for the HMAC secrets of the webhooks, e.g. "ops=s3cr3t".
+	HealthWebhookSecrets string = "HEALTH_WEBHOOK_SECRETS"
+	// HealthWebhookComponents is the configuration key for the components of each webhook, e.g. "ops=Redis|overall".
+	HealthWebhookComponents string = "HEALTH_WEBHOOK_COMPONENTS"
+	// HealthWebhookSeverities is the configuration key for the minimum severity of each webhook, e.g. "ops=critical".
+	HealthWebhookSeverities string = "HEALTH_WEBHOOK_SEVERITIES"
+	// HealthWebhookRetries is the configuration key for the number of retries of a failed webhook delivery.
+	HealthWebhookRetries string = "HEALTH_WEBHOOK_RETRIES"
+	// HealthWebhookBackoff is the configuration key for the wait before the first retry, doubled on every retry.
+	HealthWebhookBackoff string = "HEALTH_WEBHOOK_BACKOFF"
+	// HealthEventsExchange is the configuration key for the RabbitMQ exchange the status changes are published to.
+	HealthEventsExchange string = "HEALTH_EVENTS_EXCHANGE"
+	// HealthEventsRoutingKey is the configuration key for the routing key of the published status changes.
+	HealthEventsRoutingKey string = "HEALTH_EVENTS_ROUTING_KEY"
+	// HealthInstanceID is the configuration key for the instance identifier of the status changes.
+	HealthInstanceID string = "HEALTH_INSTANCE_ID"
+	// HealthFleetServices is the configuration key for the remote health endpoints, e.g. "catalog=https://catalog/health".
+	HealthFleetServices string = "HEALTH_FLEET_SERVICES"
+	// HealthFleetCritical is the configuration key for the comma separated names of the critical remote services.
+	HealthFleetCritical string = "HEALTH_FLEET_CRITICAL"
+	// HealthFleetTimeout is the configuration key for the timeout of polling a single remote service.
+	HealthFleetTimeout string = "HEALTH_FLEET_TIMEOUT"
+)
@@ -0,0 +1,129 @@
+// Package metrics provides the Echo middleware and handler that publish
+// Prometheus metrics about the HTTP traffic of the service.
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	mw "github.com/labstack/echo/v4/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// unmatchedRoute is the route label of the requests that do not match any route,
+// so that unknown paths do not create new series
+const unmatchedRoute = "unmatched"
+
+// Config defines the config for the metrics middleware.
+type Config struct {
+	// Registerer is where the metrics are registered, prometheus.DefaultRegisterer when nil
+	Registerer prometheus.Registerer
+
+	// Skipper defines a function to skip middleware.
+	Skipper mw.Skipper
+}
+
+// httpMetrics holds the RED metrics of the HTTP traffic
+type httpMetrics struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// MiddlewareWithConfig returns a middleware that records the requests, errors and duration
+// of every request, labeled by method, route and status code.
+// The route is the path template, e.g. "/users/:id", never the raw path.
+func MiddlewareWithConfig(cfg Config) echo.MiddlewareFunc {
+	// Defaults
+	if cfg.Registerer == nil {
+		cfg.Registerer = prometheus.DefaultRegisterer
+	}
+
+	if cfg.Skipper == nil {
+		cfg.Skipper = mw.DefaultSkipper
+	}
+
+	metrics := newHTTPMetrics(cfg.Registerer)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			if cfg.Skipper(ctx) {
+				return next(ctx)
+			}
+
+			start := time.Now()
+			err := next(ctx)
+
+			code := status(ctx, err)
+			labels := prometheus.Labels{
+				"method": ctx.Request().Method,
+				"route":  route(ctx),
+				"status": strconv.Itoa(code),
+			}
+
+			metrics.requests.With(labels).Inc()
+			metrics.duration.With(labels).Observe(time.Since(start).Seconds())
+			if code >= http.StatusInternalServerError {
+				metrics.errors.With(labels).Inc()
+			}
+
+			return err
+		}
+	}
+}
+
+// Handler returns the handler that exposes the metrics of gatherer in the Prometheus text format
+func Handler(gatherer prometheus.Gatherer) echo.HandlerFunc {
+	return echo.WrapHandler(promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}))
+}
+
+// newHTTPMetrics registers the RED metrics in registerer
+func newHTTPMetrics(registerer prometheus.Registerer) *httpMetrics {
+	factory := promauto.With(registerer)
+	labels := []string{"method", "route", "status"}
+
+	return &httpMetrics{
+		requests: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Number of HTTP requests handled.",
+		}, labels),
+		errors: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_request_errors_total",
+			Help: "Number of HTTP requests answered with a 5xx status code.",
+		}, labels),
+		duration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Duration of the HTTP requests in seconds.",
+			Buckets: prometheus.DefBuckets,
+		}, labels),
+	}
+}
+
+// route returns the route template that matched the request
+func route(ctx echo.Context) string {
+	if path := ctx.Path(); path != "" {
+		return path
+	}
+
+	return unmatchedRoute
+}
+
+// status returns the status code sent to the client. When the handler returns an error
+// the response has not been written yet, so the status of the error is used instead.
+func status(ctx echo.Context, err error) int {
+	if err == nil || ctx.Response().Committed {
+		return ctx.Response().Status
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code
+	}
+
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddlewareWithConfig(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	e := echo.New()
+	e.Use(MiddlewareWithConfig(Config{
+		Registerer: registry,
+		Skipper: func(c echo.Context) bool {
+			return c.Path() == "/metrics"
+		},
+	}))
+	e.GET("/beers/:id", func(c echo.Context) error {
+		return c.String(http.StatusOK, c.Param("id"))
+	})
+	e.GET("/boom", func(echo.Context) error {
+		return errors.New("boom")
+	})
+	e.GET("/metrics", Handler(registry))
+
+	for _, path := range []string{"/beers/1", "/beers/2", "/boom", "/missing", "/metrics"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	expected := `
+# HELP http_requests_total Number of HTTP requests handled.
+# TYPE http_requests_total counter
+http_requests_total{method="GET",route="/beers/:id",status="200"} 2
+http_requests_total{method="GET",route="/boom",status="500"} 1
+http_requests_total{method="GET",route="unmatched",status="404"} 1
+# HELP http_request_errors_total Number of HTTP requests answered with a 5xx status code.
+# TYPE http_request_errors_total counter
+http_request_errors_total{method="GET",route="/boom",status="500"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"http_requests_total", "http_request_errors_total"))
+	assert.Equal(t, 3, testutil.CollectAndCount(registry, "http_request_duration_seconds"))
+}
+
+func TestHandler(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_gauge", Help: "Test gauge."}))
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(httptest.NewRequest(http.MethodGet, "/metrics", nil), rec)
+
+	assert.NoError(t, Handler(registry)(ctx))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "test_gauge 0")
+}
@@ -3,6 +3,7 @@
 package broker
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -134,6 +135,136 @@ func (c *clientImpl) Ping() error {
 	return nil
 }
 
+// ServerVersion returns the product and version advertised by the broker.
+//
+// The values are read from the server properties sent by RabbitMQ when the
+// connection was opened, so no round trip to the broker is made.
+//
+// The method is thread-safe and can be called concurrently.
+//
+// Returns an error if:
+//   - The connection is nil or closed
+//   - The server properties do not hold a version
+func (c *clientImpl) ServerVersion() (string, error) {
+	// Lock mutex to ensure thread-safe access to the connection
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.connection == nil || c.connection.IsClosed() {
+		return "", fmt.Errorf("rabbitmq connection is closed")
+	}
+
+	version, _ := c.connection.Properties["version"].(string)
+	if version == "" {
+		return "", fmt.Errorf("rabbitmq server did not advertise its version")
+	}
+
+	if product, _ := c.connection.Properties["product"].(string); product != "" {
+		return product + " " + version, nil
+	}
+
+	return version, nil
+}
+
+// InspectQueue returns the number of messages and consumers of an existing queue.
+//
+// The queue is declared passively on a short-lived channel of the current connection:
+// declaring a missing queue makes the broker close the channel it was declared on,
+// and that must not be the channel shared by the publishers and consumers.
+//
+// The method is thread-safe and can be called concurrently.
+//
+// Returns an error if:
+//   - The connection is nil or closed
+//   - The queue does not exist
+func (c *clientImpl) InspectQueue(name string) (tools.QueueInfo, error) {
+	// Lock mutex to ensure thread-safe access to the connection
+	c.mu.Lock()
+	conn := c.connection
+	c.mu.Unlock()
+
+	if conn == nil || conn.IsClosed() {
+		return tools.QueueInfo{}, fmt.Errorf("rabbitmq connection is closed")
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return tools.QueueInfo{}, fmt.Errorf("failed to open an inspection channel: %w", err)
+	}
+	defer func() {
+		_ = ch.Close() // Already closed by the broker when the queue does not exist
+	}()
+
+	queue, err := ch.QueueDeclarePassive(name, false, false, false, false, nil)
+	if err != nil {
+		return tools.QueueInfo{}, fmt.Errorf("failed to inspect queue %q: %w", name, err)
+	}
+
+	return tools.QueueInfo{
+		Name:      queue.Name,
+		Messages:  queue.Messages,
+		Consumers: queue.Consumers,
+	}, nil
+}
+
+// Publish sends a persistent JSON message to an exchange with a routing key.
+//
+// The message is published on a short-lived channel in confirm mode: publishing to
+// a missing exchange makes the broker close the channel it was published on, and that
+// must not be the channel shared by the publishers and consumers. Waiting for the
+// confirmation reports that failure instead of losing the message silently.
+//
+// The method is thread-safe and can be called concurrently.
+//
+// Returns an error if:
+//   - The connection is nil or closed
+//   - The exchange does not exist or the broker rejected the message
+//   - ctx is done before the broker confirmed the message
+func (c *clientImpl) Publish(ctx context.Context, exchange, routingKey string, body []byte) error {
+	// Lock mutex to ensure thread-safe access to the connection
+	c.mu.Lock()
+	conn := c.connection
+	c.mu.Unlock()
+
+	if conn == nil || conn.IsClosed() {
+		return fmt.Errorf("rabbitmq connection is closed")
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("failed to open a publishing channel: %w", err)
+	}
+	defer func() {
+		_ = ch.Close() // Already closed by the broker when the exchange does not exist
+	}()
+
+	if err = ch.Confirm(false); err != nil {
+		return fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(ctx, exchange, routingKey, false, false,
+		amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			Timestamp:    time.Now(),
+			Body:         body,
+		})
+	if err != nil {
+		return fmt.Errorf("failed to publish to exchange %q: %w", exchange, err)
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("publishing to exchange %q was not confirmed: %w", exchange, err)
+	}
+
+	if !acked {
+		return fmt.Errorf("publishing to exchange %q was rejected by the broker", exchange)
+	}
+
+	return nil
+}
+
 // establishLocalConnection creates a standard (non-TLS) AMQP connection and channel.
 //
 // This internal method uses the "amqp://" protocol. It is specifically
@@ -1,5 +1,11 @@
 package broker
 
+import (
+	"context"
+
+	tools "github.com/samuskitchen/go-health-checker/pkg/tools/models"
+)
+
 // Client defines the interface for the concurrent RabbitMQ client.
 //
 // The Client interface provides methods for connecting to RabbitMQ,
@@ -37,4 +43,24 @@ type Client interface {
 	//
 	// Returns an error if the connection or channel is closed or not initialized.
 	Ping() error
+
+	// ServerVersion returns the product and version advertised by the broker,
+	// e.g. "RabbitMQ 3.13.7".
+	//
+	// Returns an error if the connection is closed or the broker did not advertise a version.
+	ServerVersion() (string, error)
+
+	// InspectQueue returns the number of messages and consumers of an existing queue.
+	//
+	// The queue is declared passively, so it is never created nor modified.
+	//
+	// Returns an error if the connection is closed or the queue does not exist.
+	InspectQueue(name string) (tools.QueueInfo, error)
+
+	// Publish sends a persistent JSON message to an exchange with a routing key
+	// and waits for the broker to confirm it.
+	//
+	// Returns an error if the connection is closed, the exchange does not exist
+	// or the broker did not confirm the message before ctx is done.
+	Publish(ctx context.Context, exchange, routingKey string, body []byte) error
 }
@@ -6,8 +6,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+	"github.com/hazelcast/hazelcast-go-client/types"
 	tools "github.com/samuskitchen/go-health-checker/pkg/tools/models"
 
 	"github.com/rs/zerolog/log"
@@ -15,7 +19,67 @@ import (
 
 // ClientHazelcast is the concrete implementation with enhanced type operations.
 type ClientHazelcast struct {
-	Client *hazelcast.Client
+	Client  *hazelcast.Client
+	members *memberSet
+}
+
+// memberSet is the client's view of the members of the cluster, kept up to date by a membership listener
+type memberSet struct {
+	mu      sync.RWMutex
+	members map[types.UUID]cluster.MemberInfo
+}
+
+// newMemberSet creates an empty memberSet
+func newMemberSet() *memberSet {
+	return &memberSet{members: map[types.UUID]cluster.MemberInfo{}}
+}
+
+// update records a member joining or leaving the cluster
+func (ms *memberSet) update(event cluster.MembershipStateChanged) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	switch event.State {
+	case cluster.MembershipStateAdded:
+		ms.members[event.Member.UUID] = event.Member
+	case cluster.MembershipStateRemoved:
+		delete(ms.members, event.Member.UUID)
+	}
+}
+
+// count returns the number of known members, zero for a nil memberSet
+func (ms *memberSet) count() int {
+	if ms == nil {
+		return 0
+	}
+
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	return len(ms.members)
+}
+
+// version returns the lowest version of the members, reporting false when no member is known
+func (ms *memberSet) version() (cluster.MemberVersion, bool) {
+	if ms == nil {
+		return cluster.MemberVersion{}, false
+	}
+
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	var lowest cluster.MemberVersion
+	found := false
+	for _, member := range ms.members {
+		version := member.Version
+		if !found || version.MajorMinor() < lowest.MajorMinor() ||
+			(version.MajorMinor() == lowest.MajorMinor() && version.Patch < lowest.Patch) {
+			lowest = version
+			found = true
+		}
+	}
+
+	return lowest, found
 }
 
 // NewClientHazelcast initializes and returns a new Hazelcast client instance.
@@ -41,7 +105,11 @@ func NewClientHazelcast(config tools.Config) (IClient, error) {
 		return nil, errors.New("missing required field: clusterName")
 	}
 
+	// Registered before starting the client, so that it is told about the members it connects to
+	members := newMemberSet()
 	hzConfig := config.ToHazelcastConfig()
+	hzConfig.AddMembershipListener(members.update)
+
 	hzClient, err := hazelcast.StartNewClientWithConfig(context.Background(), hzConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start Hazelcast client: %w", err)
@@ -49,7 +117,8 @@ func NewClientHazelcast(config tools.Config) (IClient, error) {
 
 	log.Info().Msgf("Successfully connected to Hazelcast cluster: %s", config.ClusterName)
 	return &ClientHazelcast{
-		Client: hzClient,
+		Client:  hzClient,
+		members: members,
 	}, nil
 }
 
@@ -85,3 +154,77 @@ func (ch *ClientHazelcast) Ping() error {
 
 	return nil
 }
+
+// ServerVersion returns the version of the Hazelcast cluster.
+//
+// The cluster runs at the version of its oldest member, reported here as the lowest
+// version among the members known by the client.
+//
+// Returns an error if:
+//   - The client is nil or not running
+//   - The client does not know any member of the cluster yet
+func (ch *ClientHazelcast) ServerVersion() (string, error) {
+	if err := ch.Ping(); err != nil {
+		return "", err
+	}
+
+	version, ok := ch.members.version()
+	if !ok {
+		return "", fmt.Errorf("hazelcast cluster has no known members")
+	}
+
+	return version.String(), nil
+}
+
+// MemberCount returns the number of members in the client's view of the cluster.
+//
+// Returns zero when the client is nil or not running.
+func (ch *ClientHazelcast) MemberCount() int {
+	if ch.Ping() != nil {
+		return 0
+	}
+
+	return ch.members.count()
+}
+
+// RoundTrip puts an entry with the given TTL in the named map, reads it back and deletes it.
+//
+// Each call uses its own key, so concurrent round trips do not interfere with each other,
+// and the TTL removes the entry even if the delete never reaches the cluster.
+//
+// Returns an error if:
+//   - The client is nil or not running
+//   - Any of the map operations fails
+//   - The value read back is not the one that was put
+func (ch *ClientHazelcast) RoundTrip(ctx context.Context, mapName string, ttl time.Duration) error {
+	if err := ch.Ping(); err != nil {
+		return err
+	}
+
+	hzMap, err := ch.Client.GetMap(ctx, mapName)
+	if err != nil {
+		return fmt.Errorf("failed to get map %q: %w", mapName, err)
+	}
+
+	key := fmt.Sprintf("%s-%d", ch.Client.Name(), time.Now().UnixNano())
+	value := time.Now().Format(time.RFC3339Nano)
+
+	if err = hzMap.SetWithTTL(ctx, key, value, ttl); err != nil {
+		return fmt.Errorf("failed to put the round trip entry: %w", err)
+	}
+
+	got, err := hzMap.Get(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to get the round trip entry: %w", err)
+	}
+
+	if got != value {
+		return fmt.Errorf("round trip entry mismatch: got %v, want %v", got, value)
+	}
+
+	if err = hzMap.Delete(ctx, key); err != nil {
+		return fmt.Errorf("failed to delete the round trip entry: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package datastore
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	tools "github.com/samuskitchen/go-health-checker/pkg/tools/models"
+
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClientHazelcast(t *testing.T) {
+	_, err := NewClientHazelcast(tools.Config{ClusterName: "dev"})
+	assert.EqualError(t, err, "missing required field: addresses")
+
+	_, err = NewClientHazelcast(tools.Config{Addresses: []string{"localhost:5701"}})
+	assert.EqualError(t, err, "missing required field: clusterName")
+}
+
+func TestMemberSet(t *testing.T) {
+	members := newMemberSet()
+	_, ok := members.version()
+	assert.False(t, ok)
+
+	older := cluster.MemberInfo{UUID: types.NewUUID(), Version: cluster.MemberVersion{Major: 5, Minor: 3, Patch: 8}}
+	newer := cluster.MemberInfo{UUID: types.NewUUID(), Version: cluster.MemberVersion{Major: 5, Minor: 4, Patch: 1}}
+	members.update(cluster.MembershipStateChanged{Member: newer, State: cluster.MembershipStateAdded})
+	members.update(cluster.MembershipStateChanged{Member: older, State: cluster.MembershipStateAdded})
+
+	assert.Equal(t, 2, members.count())
+	version, ok := members.version()
+	assert.True(t, ok)
+	assert.Equal(t, "5.3.8", version.String(), "the cluster runs at the version of its oldest member")
+
+	members.update(cluster.MembershipStateChanged{Member: older, State: cluster.MembershipStateRemoved})
+	assert.Equal(t, 1, members.count())
+	version, _ = members.version()
+	assert.Equal(t, "5.4.1", version.String())
+}
+
+func TestClientHazelcast_NotConnected(t *testing.T) {
+	client := &ClientHazelcast{}
+
+	assert.EqualError(t, client.Ping(), "hazelcast client is not initialized")
+	_, err := client.ServerVersion()
+	assert.Error(t, err)
+	assert.Equal(t, 0, client.MemberCount())
+	assert.Error(t, client.RoundTrip(context.Background(), "health", time.Minute))
+}
+
+// TestClientHazelcast_Cluster runs against the cluster at HAZELCAST_TEST_ADDRESS, e.g. a local hazelcast/hazelcast
+// container with the "dev" cluster name, and is skipped when it is not set
+func TestClientHazelcast_Cluster(t *testing.T) {
+	address := os.Getenv("HAZELCAST_TEST_ADDRESS")
+	if address == "" {
+		t.Skip("HAZELCAST_TEST_ADDRESS is not set")
+	}
+
+	client, err := NewClientHazelcast(tools.Config{Addresses: []string{address}, ClusterName: "dev"})
+	assert.NoError(t, err)
+	defer func() { _ = client.Disconnect(context.Background()) }()
+
+	assert.NoError(t, client.Ping())
+	probe := client.(ICacheProbe)
+	assert.Positive(t, probe.MemberCount())
+	version, err := client.ServerVersion()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, version)
+	assert.NoError(t, probe.RoundTrip(context.Background(), "health-check", time.Minute))
+}
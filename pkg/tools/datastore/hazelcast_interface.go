@@ -1,17 +1,42 @@
 // Package datastore provides a generic interface and helper types
-// to connect to and operate against a Hazelcast cluster in a
+// to connect to and operate against a Hazelcast cluster or a Redis server in a
 // typed and configurable way.
 package datastore
 
 import (
 	"context"
+	"time"
 )
 
-// IClient defines the generic interface for a type-safe Hazelcast client.
+// IClient defines the generic interface for a type-safe cache client,
+// implemented by ClientHazelcast and ClientRedis.
 type IClient interface {
-	// Disconnect gracefully shuts down the connection to the Hazelcast cluster.
+	// Disconnect gracefully shuts down the connection to the cache server.
 	Disconnect(ctx context.Context) error
 
-	// Ping verifies that the Hazelcast client connection is active and healthy.
+	// Ping verifies that the cache client connection is active and healthy.
 	Ping() error
+
+	// ServerVersion returns the version of the cache server.
+	ServerVersion() (string, error)
+}
+
+// ICacheProbe is implemented by the cache clients that support the deep health check,
+// such as ClientHazelcast.
+type ICacheProbe interface {
+	// MemberCount returns the number of members in the client's view of the cluster.
+	MemberCount() int
+
+	// RoundTrip puts an entry with the given TTL in the named map, reads it back and deletes it.
+	RoundTrip(ctx context.Context, mapName string, ttl time.Duration) error
+}
+
+// IContextClient is implemented by the cache clients whose calls honor the deadline and
+// cancellation of a context, such as ClientRedis.
+type IContextClient interface {
+	// PingContext verifies that the cache client connection is active and healthy.
+	PingContext(ctx context.Context) error
+
+	// ServerVersionContext returns the version of the cache server.
+	ServerVersionContext(ctx context.Context) (string, error)
 }
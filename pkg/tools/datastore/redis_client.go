@@ -0,0 +1,118 @@
+package datastore
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tools "github.com/samuskitchen/go-health-checker/pkg/tools/models"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+)
+
+// ClientRedis is the Redis implementation of IClient.
+type ClientRedis struct {
+	Client *redis.Client
+}
+
+// NewClientRedis initializes and returns a new Redis client instance.
+//
+// This method builds the Redis client settings from the provided configuration
+// and verifies the connection with a PING before returning the client.
+//
+// Returns:
+//   - A valid IClient implementation if the connection is successful.
+//   - An error if required configuration fields are missing or the connection cannot be established.
+//
+// Expected errors:
+//   - "missing required field: address" if no server address is provided.
+//   - "failed to connect to Redis" if the server does not answer the PING.
+func NewClientRedis(config tools.RedisConfig) (IClient, error) {
+	if config.Address == "" {
+		return nil, errors.New("missing required field: address")
+	}
+
+	client := redis.NewClient(config.ToRedisOptions())
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	log.Info().Msgf("Successfully connected to Redis server: %s", config.Address)
+	return &ClientRedis{
+		Client: client,
+	}, nil
+}
+
+// Disconnect gracefully closes the Redis client and its connection pool.
+//
+// Returns an error if the client is already closed.
+func (cr *ClientRedis) Disconnect(_ context.Context) error {
+	log.Info().Msgf("Disconnecting from Redis server: %s", cr.Client.Options().Addr)
+	return cr.Client.Close()
+}
+
+// Ping verifies that the Redis server answers a PING.
+//
+// Returns an error if:
+//   - The client is nil
+//   - The server cannot be reached or does not answer in time
+func (cr *ClientRedis) Ping() error {
+	return cr.PingContext(context.Background())
+}
+
+// PingContext verifies that the Redis server answers a PING before ctx is done.
+//
+// Returns an error if:
+//   - The client is nil
+//   - The server cannot be reached or does not answer before ctx is done
+func (cr *ClientRedis) PingContext(ctx context.Context) error {
+	if cr.Client == nil {
+		return fmt.Errorf("redis client is not initialized")
+	}
+
+	return cr.Client.Ping(ctx).Err()
+}
+
+// ServerVersion returns the version of the Redis server, read from the server section of INFO.
+//
+// Returns an error if:
+//   - The client is nil or the server cannot be reached
+//   - The server does not report its version
+func (cr *ClientRedis) ServerVersion() (string, error) {
+	return cr.ServerVersionContext(context.Background())
+}
+
+// ServerVersionContext returns the version of the Redis server, read from the server section of INFO
+// before ctx is done.
+//
+// Returns an error if:
+//   - The client is nil or the server cannot be reached before ctx is done
+//   - The server does not report its version
+func (cr *ClientRedis) ServerVersionContext(ctx context.Context) (string, error) {
+	if err := cr.PingContext(ctx); err != nil {
+		return "", err
+	}
+
+	info, err := cr.Client.Info(ctx, "server").Result()
+	if err != nil {
+		return "", err
+	}
+
+	return redisVersion(info)
+}
+
+// redisVersion extracts the redis_version field from the output of INFO
+func redisVersion(info string) (string, error) {
+	scanner := bufio.NewScanner(strings.NewReader(info))
+	for scanner.Scan() {
+		if version, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "redis_version:"); ok {
+			return version, nil
+		}
+	}
+
+	return "", fmt.Errorf("redis server did not report its version")
+}
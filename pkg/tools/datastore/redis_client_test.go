@@ -0,0 +1,70 @@
+package datastore
+
+import (
+	"context"
+	"testing"
+
+	tools "github.com/samuskitchen/go-health-checker/pkg/tools/models"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClientRedis(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	_, err := NewClientRedis(tools.RedisConfig{})
+	assert.EqualError(t, err, "missing required field: address")
+
+	client, err := NewClientRedis(tools.RedisConfig{Address: server.Addr(), ClientName: "health-checker"})
+	assert.NoError(t, err)
+	assert.NoError(t, client.Ping())
+
+	assert.NoError(t, client.Disconnect(context.Background()))
+	assert.Error(t, client.Ping())
+}
+
+func TestNewClientRedis_Unreachable(t *testing.T) {
+	server := miniredis.RunT(t)
+	address := server.Addr()
+	server.Close()
+
+	_, err := NewClientRedis(tools.RedisConfig{Address: address})
+	assert.ErrorContains(t, err, "failed to connect to Redis")
+}
+
+func TestClientRedis_PingContext(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	client, err := NewClientRedis(tools.RedisConfig{Address: server.Addr()})
+	assert.NoError(t, err)
+	defer client.Disconnect(context.Background())
+
+	redisClient := client.(IContextClient)
+	assert.NoError(t, redisClient.PingContext(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, redisClient.PingContext(ctx), context.Canceled)
+	_, err = redisClient.ServerVersionContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestClientRedis_ServerVersion(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	client, err := NewClientRedis(tools.RedisConfig{Address: server.Addr()})
+	assert.NoError(t, err)
+	defer client.Disconnect(context.Background())
+
+	// The stand-in does not implement the server section of INFO
+	_, err = client.ServerVersion()
+	assert.Error(t, err)
+
+	version, err := redisVersion("# Server\r\nredis_version:7.2.4\r\nredis_mode:standalone\r\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "7.2.4", version)
+
+	_, err = redisVersion("# Clients\r\nconnected_clients:1\r\n")
+	assert.Error(t, err)
+}
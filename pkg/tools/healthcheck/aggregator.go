@@ -0,0 +1,251 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// remoteResponseDetail is the detail holding the Response parsed by a RemoteChecker
+const remoteResponseDetail = "response"
+
+// RemoteChecker checks a remote service through the health endpoint it exposes with this package.
+// The check passes when the service is Available, is degraded when the service is Partially Available
+// and fails otherwise, or when the endpoint is unreachable or does not answer a health response.
+type RemoteChecker struct {
+	// URL is the health endpoint of the service, e.g. "https://catalog.internal/api/health"
+	URL string
+	// Headers are added to the request, e.g. an Authorization header
+	Headers map[string]string
+	// Client performs the request, http.DefaultClient when nil.
+	// The timeout of the request is the timeout of the check.
+	Client *http.Client
+}
+
+// Check polls the service and verifies its overall status
+func (rc *RemoteChecker) Check(ctx context.Context) error {
+	_, err := rc.CheckDetails(ctx)
+	return err
+}
+
+// CheckDetails polls the service and reports the response it answered
+func (rc *RemoteChecker) CheckDetails(ctx context.Context) (map[string]any, error) {
+	resp, err := rc.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	details := map[string]any{remoteResponseDetail: resp}
+	switch resp.OverallStatus {
+	case StatusAvailable:
+		return details, nil
+	case StatusDegraded:
+		return details, fmt.Errorf("%w: service is %s", ErrDegraded, resp.OverallStatus)
+	default:
+		return details, fmt.Errorf("service is %s", resp.OverallStatus)
+	}
+}
+
+// fetch calls the health endpoint and parses its response.
+// Any status code is accepted as long as the body is a health response,
+// since an unhealthy service answers 503 with the reason in the body.
+func (rc *RemoteChecker) fetch(ctx context.Context) (Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rc.URL, http.NoBody)
+	if err != nil {
+		return Response{}, fmt.Errorf("invalid request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	for key, value := range rc.Headers {
+		req.Header.Set(key, value)
+	}
+
+	client := rc.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	httpResp, err := client.Do(req)
+	if err != nil {
+		return Response{}, err
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(httpResp.Body, maxHTTPBody))
+	if err != nil {
+		return Response{}, fmt.Errorf("failed to read the response body: %w", err)
+	}
+
+	var resp Response
+	if err = json.Unmarshal(body, &resp); err != nil || resp.OverallStatus == "" {
+		if err == nil {
+			err = errors.New("missing overallStatus")
+		}
+		return Response{}, fmt.Errorf("malformed health response with status code %d: %w", httpResp.StatusCode, err)
+	}
+
+	return resp, nil
+}
+
+// FleetResponse is the combined health of the remote services polled by an Aggregator
+type FleetResponse struct {
+	// OverallStatus is the status of the fleet, computed from the services like the status of a single service
+	// is computed from its checks
+	OverallStatus Status `json:"overallStatus"`
+	// Score is the weighted share of healthy services, from 0 to 1
+	Score     float64         `json:"score"`
+	Timestamp string          `json:"timestamp"`
+	Services  []ServiceHealth `json:"services"`
+}
+
+// ServiceHealth is the health of a remote service as seen by the aggregator
+type ServiceHealth struct {
+	Service string `json:"service"`
+	// Status is OK, Degraded or Unavailable after the overall status the service reported,
+	// Unavailable or Timeout when it could not be polled
+	Status   Status `json:"status"`
+	Critical bool   `json:"critical"`
+	// DurationMs is how long polling the service took in milliseconds
+	DurationMs float64 `json:"durationMs,omitempty"`
+	// Error is the reason the service is not healthy or could not be polled
+	Error               string     `json:"error,omitempty"`
+	LastSuccess         *time.Time `json:"lastSuccess,omitempty"`
+	LastFailure         *time.Time `json:"lastFailure,omitempty"`
+	ConsecutiveFailures int        `json:"consecutiveFailures,omitempty"`
+	// Remote is the response of the service, omitted when it could not be polled or parsed
+	Remote *Response `json:"remote,omitempty"`
+}
+
+// Service returns the health of the named service, reporting false when it is not polled
+func (f FleetResponse) Service(name string) (ServiceHealth, bool) {
+	for _, service := range f.Services {
+		if service.Service == name {
+			return service, true
+		}
+	}
+
+	return ServiceHealth{}, false
+}
+
+// Component returns the health of the named component of the service,
+// reporting false when the service did not report it
+func (s ServiceHealth) Component(name string) (Health, bool) {
+	if s.Remote == nil {
+		return Health{}, false
+	}
+
+	for _, check := range s.Remote.Checks {
+		if check.Component == name {
+			return check, true
+		}
+	}
+
+	return Health{}, false
+}
+
+// WithVerbosity returns a copy of the fleet response that only holds the details allowed by verbosity
+func (f FleetResponse) WithVerbosity(verbosity Verbosity) FleetResponse {
+	if verbosity != VerbositySummary {
+		return f
+	}
+
+	services := make([]ServiceHealth, len(f.Services))
+	for i, service := range f.Services {
+		services[i] = ServiceHealth{
+			Service:  service.Service,
+			Status:   service.Status,
+			Critical: service.Critical,
+		}
+
+		if service.Remote != nil {
+			remote := service.Remote.WithVerbosity(verbosity)
+			services[i].Remote = &remote
+		}
+	}
+
+	f.Services = services
+	return f
+}
+
+// AggregatorConfig configures the remote services polled by an Aggregator
+type AggregatorConfig struct {
+	// Services are the remote services, keyed by service name
+	Services map[string]*RemoteChecker
+	// Critical marks the services the fleet cannot work without, keyed by service name
+	Critical map[string]bool
+	// Timeout bounds the polling of each service, DefaultTimeout when zero
+	Timeout time.Duration
+	// Interval is the background polling interval, a zero interval polls on every request
+	Interval time.Duration
+	// Deadline bounds a whole polling run, DefaultTimeout when zero
+	Deadline time.Duration
+}
+
+// Aggregator polls the health endpoints of remote services concurrently and combines them into a fleet view.
+// Every service is a check of its own registry, so a service that cannot be polled is reported
+// as Unavailable or Timeout instead of failing the whole fleet.
+type Aggregator struct {
+	// Scheduler polls the services in the background, its observers see every service as a check
+	Scheduler *Scheduler
+}
+
+// NewAggregator creates an aggregator of the services of config without starting the background polling
+func NewAggregator(config AggregatorConfig) *Aggregator {
+	registry := NewRegistry()
+	for _, name := range slices.Sorted(maps.Keys(config.Services)) {
+		_ = registry.Register(Config{
+			Name:     name,
+			Timeout:  config.Timeout,
+			Critical: config.Critical[name],
+			Checker:  config.Services[name],
+		})
+	}
+
+	return &Aggregator{Scheduler: NewScheduler(registry, config.Interval, config.Deadline)}
+}
+
+// Fleet returns the latest snapshot of the background polling,
+// polling the services right away when there is none yet or fresh is set
+func (a *Aggregator) Fleet(ctx context.Context, fresh bool) FleetResponse {
+	if !fresh {
+		if resp, ok := a.Scheduler.Snapshot(); ok {
+			return fleetResponse(resp)
+		}
+	}
+
+	return fleetResponse(a.Scheduler.Check(ctx))
+}
+
+// fleetResponse converts the response of the aggregator registry, whose checks are the services
+func fleetResponse(resp Response) FleetResponse {
+	services := make([]ServiceHealth, len(resp.Checks))
+	for i, check := range resp.Checks {
+		services[i] = ServiceHealth{
+			Service:             check.Component,
+			Status:              check.Status,
+			Critical:            check.Critical,
+			DurationMs:          check.DurationMs,
+			Error:               check.Error,
+			LastSuccess:         check.LastSuccess,
+			LastFailure:         check.LastFailure,
+			ConsecutiveFailures: check.ConsecutiveFailures,
+		}
+
+		if remote, ok := check.Details[remoteResponseDetail].(Response); ok {
+			services[i].Remote = &remote
+		}
+	}
+
+	return FleetResponse{
+		OverallStatus: resp.OverallStatus,
+		Score:         resp.Score,
+		Timestamp:     resp.Timestamp,
+		Services:      services,
+	}
+}
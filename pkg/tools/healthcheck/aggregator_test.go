@@ -0,0 +1,122 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// healthServer answers every request with resp and code
+func healthServer(t *testing.T, code int, resp any) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestRemoteChecker_CheckDetails(t *testing.T) {
+	ctx := context.Background()
+
+	available := healthServer(t, http.StatusOK, Response{
+		OverallStatus: StatusAvailable,
+		Checks:        []Health{{Component: "Redis", Status: StatusOK}},
+	})
+	details, err := (&RemoteChecker{URL: available.URL}).CheckDetails(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusAvailable, details[remoteResponseDetail].(Response).OverallStatus)
+
+	degraded := healthServer(t, http.StatusMultiStatus, Response{OverallStatus: StatusDegraded})
+	_, err = (&RemoteChecker{URL: degraded.URL}).CheckDetails(ctx)
+	assert.ErrorIs(t, err, ErrDegraded)
+
+	unavailable := healthServer(t, http.StatusServiceUnavailable, Response{OverallStatus: StatusUnavailable})
+	details, err = (&RemoteChecker{URL: unavailable.URL}).CheckDetails(ctx)
+	assert.EqualError(t, err, "service is Unavailable")
+	assert.NotNil(t, details, "the response of an unavailable service is still reported")
+
+	malformed := healthServer(t, http.StatusOK, map[string]string{"status": "UP"})
+	_, err = (&RemoteChecker{URL: malformed.URL}).CheckDetails(ctx)
+	assert.EqualError(t, err, "malformed health response with status code 200: missing overallStatus")
+
+	notJSON := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte("<html>bad gateway</html>"))
+	}))
+	defer notJSON.Close()
+	_, err = (&RemoteChecker{URL: notJSON.URL}).CheckDetails(ctx)
+	assert.ErrorContains(t, err, "malformed health response with status code 502")
+}
+
+func TestAggregator_Fleet(t *testing.T) {
+	catalog := healthServer(t, http.StatusOK, Response{
+		OverallStatus: StatusAvailable,
+		Checks: []Health{
+			{Component: "postgresql-sql", Status: StatusOK, Version: "16.4", Critical: true},
+			{Component: "Redis", Status: StatusOK},
+		},
+	})
+	orders := healthServer(t, http.StatusMultiStatus, Response{
+		OverallStatus: StatusDegraded,
+		Checks: []Health{
+			{Component: "RabbitMQ", Status: StatusDegraded, Error: "degraded: queue backing up"},
+		},
+	})
+
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	aggregator := NewAggregator(AggregatorConfig{
+		Services: map[string]*RemoteChecker{
+			"catalog":  {URL: catalog.URL},
+			"orders":   {URL: orders.URL},
+			"payments": {URL: unreachable.URL},
+		},
+		Critical: map[string]bool{"catalog": true},
+		Timeout:  time.Second,
+	})
+
+	fleet := aggregator.Fleet(context.Background(), false)
+	assert.Equal(t, StatusDegraded, fleet.OverallStatus, "only optional services are failing")
+	assert.InDelta(t, 0.5, fleet.Score, 0.001)
+	assert.Len(t, fleet.Services, 3)
+
+	service, ok := fleet.Service("catalog")
+	assert.True(t, ok)
+	assert.Equal(t, StatusOK, service.Status)
+	assert.True(t, service.Critical)
+	component, ok := service.Component("postgresql-sql")
+	assert.True(t, ok)
+	assert.Equal(t, "16.4", component.Version)
+	_, ok = service.Component("Hazelcast")
+	assert.False(t, ok)
+
+	service, _ = fleet.Service("orders")
+	assert.Equal(t, StatusDegraded, service.Status)
+	component, _ = service.Component("RabbitMQ")
+	assert.Equal(t, "degraded: queue backing up", component.Error)
+
+	service, _ = fleet.Service("payments")
+	assert.Equal(t, StatusUnavailable, service.Status)
+	assert.Nil(t, service.Remote)
+	assert.NotEmpty(t, service.Error)
+	assert.Equal(t, 1, service.ConsecutiveFailures)
+
+	_, ok = fleet.Service("shipping")
+	assert.False(t, ok)
+
+	summary := fleet.WithVerbosity(VerbositySummary)
+	service, _ = summary.Service("orders")
+	assert.Empty(t, service.Error)
+	component, _ = service.Component("RabbitMQ")
+	assert.Empty(t, component.Error)
+	assert.Equal(t, StatusDegraded, component.Status)
+}
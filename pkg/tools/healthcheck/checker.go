@@ -0,0 +1,230 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrDuplicateCheck is returned when a check is registered twice under the same name
+var ErrDuplicateCheck = errors.New("health check already registered")
+
+// ErrDegraded is wrapped by checkers whose dependency answers but not as expected,
+// e.g. a connection pool close to exhaustion. Such a check is reported as Degraded instead of Unavailable.
+var ErrDegraded = errors.New("degraded")
+
+// Checker defines a dependency that can report its health
+type Checker interface {
+	// Check returns nil when the dependency is healthy, or the reason it is not
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc adapts an ordinary function to the Checker interface
+type CheckerFunc func(ctx context.Context) error
+
+// Check calls f(ctx)
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// VersionChecker is implemented by checkers that can look up the version of their dependency.
+// The version is looked up after every successful check and replaces Config.Version when found.
+type VersionChecker interface {
+	Version(ctx context.Context) (string, error)
+}
+
+// DetailedChecker is implemented by checkers that report details about their dependency besides its health.
+// CheckDetails is called instead of Check and its details are added to the response.
+type DetailedChecker interface {
+	CheckDetails(ctx context.Context) (map[string]any, error)
+}
+
+// Config describes a named check and the component it reports as
+type Config struct {
+	// Name identifies the check inside the registry, e.g. "postgresql-sql-connection"
+	Name string
+	// Component is the name reported in the response, e.g. "postgresql-sql"
+	Component string
+	// Version is the component version reported in the response,
+	// used when the checker is not a VersionChecker or its lookup fails
+	Version string
+	// Timeout bounds the duration of the check, DefaultTimeout is used when zero
+	Timeout time.Duration
+	// Critical marks a dependency the service cannot work without:
+	// when it fails the overall status is Unavailable instead of Degraded
+	Critical bool
+	// Weight is the share of the check in the health score, 1 when zero
+	Weight float64
+	// Interval runs the check at most this often, the result of its previous run is reported in between.
+	// A zero interval runs it on every run of the registry.
+	Interval time.Duration
+	// Tags are reported in the response to group the checks, e.g. "database"
+	Tags []string
+	// Checker performs the actual check
+	Checker Checker
+}
+
+// Registry holds the named checks that make up the health of the service
+type Registry struct {
+	mu     sync.RWMutex
+	checks []Config
+	states map[string]*checkState
+}
+
+// checkState is the history of a check across runs
+type checkState struct {
+	lastSuccess         time.Time
+	lastFailure         time.Time
+	consecutiveFailures int
+	lastRun             time.Time
+	last                Health
+}
+
+// DefaultRegistry is the registry used by Clients.CheckerHealth in addition to the built-in clients
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry creates an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{states: map[string]*checkState{}}
+}
+
+// Register adds a check to the registry. The check name must be unique.
+func (r *Registry) Register(cfg Config) error {
+	if cfg.Name == "" {
+		return errors.New("missing required field: name")
+	}
+
+	if cfg.Checker == nil {
+		return fmt.Errorf("health check %q: missing required field: checker", cfg.Name)
+	}
+
+	if cfg.Component == "" {
+		cfg.Component = cfg.Name
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, check := range r.checks {
+		if check.Name == cfg.Name {
+			return fmt.Errorf("%w: %s", ErrDuplicateCheck, cfg.Name)
+		}
+	}
+
+	r.checks = append(r.checks, cfg)
+	return nil
+}
+
+// Unregister removes the check with the given name, reporting whether it was present
+func (r *Registry) Unregister(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, check := range r.checks {
+		if check.Name == name {
+			r.checks = append(r.checks[:i:i], r.checks[i+1:]...)
+			delete(r.states, name)
+			return true
+		}
+	}
+
+	return false
+}
+
+// Checks returns a copy of the registered checks in registration order
+func (r *Registry) Checks() []Config {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	checks := make([]Config, len(r.checks))
+	copy(checks, r.checks)
+
+	return checks
+}
+
+// record updates the history of the named check with a new result and fills in its details
+func (r *Registry) record(name string, check *Health, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, ok := r.states[name]
+	if !ok {
+		state = &checkState{}
+		r.states[name] = state
+	}
+
+	if check.Status.passed() {
+		state.lastSuccess = at
+		state.consecutiveFailures = 0
+	} else {
+		state.lastFailure = at
+		state.consecutiveFailures++
+	}
+
+	check.LastSuccess = timeOrNil(state.lastSuccess)
+	check.LastFailure = timeOrNil(state.lastFailure)
+	check.ConsecutiveFailures = state.consecutiveFailures
+
+	state.lastRun = at
+	state.last = *check
+}
+
+// inherit copies the history of the checks of previous that r holds under the same name.
+// Their previous results are not reused, so they run again on the next run whatever their interval.
+func (r *Registry) inherit(previous *Registry) {
+	if previous == nil || previous == r {
+		return
+	}
+
+	previous.mu.RLock()
+	defer previous.mu.RUnlock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, check := range r.checks {
+		if state, ok := previous.states[check.Name]; ok {
+			r.states[check.Name] = &checkState{
+				lastSuccess:         state.lastSuccess,
+				lastFailure:         state.lastFailure,
+				consecutiveFailures: state.consecutiveFailures,
+			}
+		}
+	}
+}
+
+// cached returns the result of the previous run of a check whose interval has not elapsed yet
+func (r *Registry) cached(cfg Config, now time.Time) (Health, bool) {
+	if cfg.Interval <= 0 {
+		return Health{}, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	state, ok := r.states[cfg.Name]
+	if !ok || state.lastRun.IsZero() || now.Sub(state.lastRun) >= cfg.Interval {
+		return Health{}, false
+	}
+
+	last := state.last
+	last.cached = true
+
+	return last, true
+}
+
+// timeOrNil returns nil for the zero time so that it is omitted from the response
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+// Register adds a check to the DefaultRegistry
+func Register(cfg Config) error {
+	return DefaultRegistry.Register(cfg)
+}
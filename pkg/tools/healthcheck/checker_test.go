@@ -0,0 +1,140 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Register(t *testing.T) {
+	ok := CheckerFunc(func(context.Context) error { return nil })
+
+	t.Run("success", func(t *testing.T) {
+		registry := NewRegistry()
+
+		assert.NoError(t, registry.Register(Config{Name: "first", Checker: ok}))
+		assert.NoError(t, registry.Register(Config{Name: "second", Component: "Second", Checker: ok}))
+
+		checks := registry.Checks()
+		assert.Len(t, checks, 2)
+		assert.Equal(t, "first", checks[0].Component)
+		assert.Equal(t, "Second", checks[1].Component)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		registry := NewRegistry()
+
+		assert.NoError(t, registry.Register(Config{Name: "first", Checker: ok}))
+		err := registry.Register(Config{Name: "first", Checker: ok})
+		assert.ErrorIs(t, err, ErrDuplicateCheck)
+	})
+
+	t.Run("missing fields", func(t *testing.T) {
+		registry := NewRegistry()
+
+		assert.Error(t, registry.Register(Config{Checker: ok}))
+		assert.Error(t, registry.Register(Config{Name: "first"}))
+		assert.Empty(t, registry.Checks())
+	})
+}
+
+func TestRegistry_Unregister(t *testing.T) {
+	ok := CheckerFunc(func(context.Context) error { return nil })
+	registry := NewRegistry()
+
+	assert.NoError(t, registry.Register(Config{Name: "first", Checker: ok}))
+	assert.NoError(t, registry.Register(Config{Name: "second", Checker: ok}))
+
+	assert.True(t, registry.Unregister("first"))
+	assert.False(t, registry.Unregister("first"))
+
+	checks := registry.Checks()
+	assert.Len(t, checks, 1)
+	assert.Equal(t, "second", checks[0].Name)
+}
+
+func TestRegistry_CheckerHealth(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("available", func(t *testing.T) {
+		registry := NewRegistry()
+		assert.NoError(t, registry.Register(Config{
+			Name:    "custom",
+			Version: "2.0.0",
+			Checker: CheckerFunc(func(context.Context) error { return nil }),
+		}))
+
+		resp := registry.CheckerHealth(ctx)
+		assert.Equal(t, StatusAvailable, resp.OverallStatus)
+		assert.Equal(t, 1.0, resp.Score)
+		assert.Len(t, resp.Checks, 1)
+		assert.Equal(t, StatusOK, resp.Checks[0].Status)
+		assert.Equal(t, "custom", resp.Checks[0].Component)
+		assert.Equal(t, "2.0.0", resp.Checks[0].Version)
+	})
+
+	t.Run("partially available", func(t *testing.T) {
+		registry := NewRegistry()
+		assert.NoError(t, registry.Register(Config{
+			Name:    "up",
+			Checker: CheckerFunc(func(context.Context) error { return nil }),
+		}))
+		assert.NoError(t, registry.Register(Config{
+			Name:    "down",
+			Checker: CheckerFunc(func(context.Context) error { return errors.New("boom") }),
+		}))
+
+		resp := registry.CheckerHealth(ctx)
+		assert.Equal(t, StatusDegraded, resp.OverallStatus)
+		assert.Len(t, resp.Checks, 2)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		resp := NewRegistry().CheckerHealth(ctx)
+		assert.Equal(t, StatusUnknown, resp.OverallStatus)
+		assert.Empty(t, resp.Checks)
+	})
+}
+
+func TestRegistry_CheckerHealth_Interval(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	registry := NewRegistry()
+	assert.NoError(t, registry.Register(Config{
+		Name:     "slow-changing",
+		Interval: time.Hour,
+		Tags:     []string{"nightly"},
+		Checker: CheckerFunc(func(context.Context) error {
+			calls++
+			return nil
+		}),
+	}))
+
+	first := registry.CheckerHealth(ctx)
+	second := registry.CheckerHealth(ctx)
+
+	assert.Equal(t, 1, calls, "the previous result is reported until the interval elapses")
+	assert.True(t, second.Checks[0].cached)
+	second.Checks[0].cached = false
+	assert.Equal(t, first.Checks, second.Checks)
+	assert.Equal(t, []string{"nightly"}, second.Checks[0].Tags)
+}
+
+func TestClients_CheckerHealth_DefaultRegistry(t *testing.T) {
+	cfg := Config{
+		Name:    "default-registry-check",
+		Checker: CheckerFunc(func(context.Context) error { return nil }),
+	}
+	assert.NoError(t, Register(cfg))
+	t.Cleanup(func() { DefaultRegistry.Unregister(cfg.Name) })
+
+	clients := Clients{}
+	resp := clients.CheckerHealth(context.Background())
+
+	assert.Equal(t, StatusAvailable, resp.OverallStatus)
+	assert.Len(t, resp.Checks, 1)
+	assert.Equal(t, "default-registry-check", resp.Checks[0].Component)
+}
@@ -0,0 +1,118 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/samuskitchen/go-health-checker/pkg/tools/broker"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultEventRoutingKey is the routing key of the transition events when none is configured
+const DefaultEventRoutingKey = "health.transition"
+
+// eventQueueSize bounds the transitions waiting to be published
+const eventQueueSize = 100
+
+// EventConfig configures where the transition events are published
+type EventConfig struct {
+	// Exchange receives the events, the default exchange when empty
+	Exchange string
+	// RoutingKey routes the events, DefaultEventRoutingKey when empty
+	RoutingKey string
+	// Instance identifies this instance in the events
+	Instance string
+}
+
+// EventPublisher detects the transitions of the overall status and of every component between two runs
+// and publishes each of them as a JSON Transition to a RabbitMQ exchange. The first run only records the statuses.
+// The events are published in order by a single goroutine, so an unreachable broker never delays the checks.
+type EventPublisher struct {
+	client broker.Client
+	config EventConfig
+	queue  chan Transition
+
+	mu      sync.Mutex
+	tracker transitionTracker
+	closed  bool
+
+	done chan struct{}
+}
+
+// NewEventPublisher starts publishing the transitions with client until Close is called
+func NewEventPublisher(client broker.Client, config EventConfig) *EventPublisher {
+	if config.RoutingKey == "" {
+		config.RoutingKey = DefaultEventRoutingKey
+	}
+
+	p := &EventPublisher{
+		client:  client,
+		config:  config,
+		queue:   make(chan Transition, eventQueueSize),
+		tracker: transitionTracker{instance: config.Instance},
+		done:    make(chan struct{}),
+	}
+
+	go p.publishAll()
+	return p
+}
+
+// ObserveHealth queues the transitions since the previous run
+func (p *EventPublisher) ObserveHealth(resp Response) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return
+	}
+
+	for _, transition := range p.tracker.transitions(resp) {
+		select {
+		case p.queue <- transition:
+		default:
+			log.Warn().Msgf("Warning: health events are backing up, dropping the %s transition", transition.Component)
+		}
+	}
+}
+
+// Close stops accepting transitions and waits for the queued ones to be published
+func (p *EventPublisher) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+
+	p.closed = true
+	close(p.queue)
+	p.mu.Unlock()
+
+	<-p.done
+}
+
+// publishAll publishes every transition of the queue until it is closed
+func (p *EventPublisher) publishAll() {
+	defer close(p.done)
+
+	for transition := range p.queue {
+		if err := p.publish(transition); err != nil {
+			log.Warn().Msgf("Warning: failed to publish the %s transition to exchange %q: %v",
+				transition.Component, p.config.Exchange, err)
+		}
+	}
+}
+
+// publish sends a single transition, waiting at most DefaultTimeout for the broker to confirm it
+func (p *EventPublisher) publish(transition Transition) error {
+	body, err := json.Marshal(transition)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+
+	return p.client.Publish(ctx, p.config.Exchange, p.config.RoutingKey, body)
+}
@@ -0,0 +1,70 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+
+	_mockToolsBroker "github.com/samuskitchen/go-health-checker/pkg/tools/mocks/broker"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEventPublisher(t *testing.T) {
+	mockBroker := _mockToolsBroker.NewMockClient(t)
+
+	var mu sync.Mutex
+	var events []map[string]any
+	mockBroker.EXPECT().Publish(mock.Anything, "health", DefaultEventRoutingKey, mock.Anything).
+		Run(func(_ context.Context, _ string, _ string, body []byte) {
+			var event map[string]any
+			_ = json.Unmarshal(body, &event)
+
+			mu.Lock()
+			events = append(events, event)
+			mu.Unlock()
+		}).
+		Return(nil).Once()
+	mockBroker.EXPECT().Publish(mock.Anything, "health", DefaultEventRoutingKey, mock.Anything).
+		Return(errors.New("rabbitmq connection is closed")).Once()
+
+	publisher := NewEventPublisher(mockBroker, EventConfig{Exchange: "health", Instance: "beer-api-0"})
+
+	// The first run only records the statuses
+	publisher.ObserveHealth(Response{
+		OverallStatus: StatusAvailable,
+		Checks:        []Health{{Component: "Redis", Status: StatusOK}},
+		Timestamp:     "2025-01-01T00:00:00Z",
+	})
+	publisher.ObserveHealth(Response{
+		OverallStatus: StatusAvailable,
+		Checks:        []Health{{Component: "Redis", Status: StatusOK}},
+		Timestamp:     "2025-01-01T00:00:10Z",
+	})
+	publisher.ObserveHealth(Response{
+		OverallStatus: StatusUnavailable,
+		Checks: []Health{
+			{Component: "Redis", Status: StatusUnavailable, Critical: true, Error: "connection refused"},
+		},
+		Timestamp: "2025-01-01T00:00:20Z",
+	})
+	publisher.Close()
+
+	// Later runs are ignored once closed
+	publisher.ObserveHealth(Response{OverallStatus: StatusAvailable})
+
+	// The failed publication of the Redis transition is only logged
+	assert.Len(t, events, 1)
+	assert.Equal(t, map[string]any{
+		"component":      OverallComponent,
+		"previousStatus": "Available",
+		"status":         "Unavailable",
+		"severity":       "critical",
+		"critical":       true,
+		"timestamp":      "2025-01-01T00:00:20Z",
+		"instance":       "beer-api-0",
+	}, events[0])
+}
@@ -0,0 +1,62 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// maxExecMessage bounds the part of the command output reported as the error of the check
+const maxExecMessage = 200
+
+// ExecChecker runs a command, e.g. a Nagios plugin, and checks its exit code:
+// 0 passes the check, 1 degrades it and any other code fails it.
+// The first line of the output of the command is reported as the reason.
+type ExecChecker struct {
+	// Command is the program to run followed by its arguments, it is not run through a shell
+	Command []string
+}
+
+// Check runs the command, killing it when ctx is done
+func (ec *ExecChecker) Check(ctx context.Context) error {
+	if len(ec.Command) == 0 {
+		return errors.New("missing required field: command")
+	}
+
+	output, err := exec.CommandContext(ctx, ec.Command[0], ec.Command[1:]...).CombinedOutput()
+	if err == nil {
+		return nil
+	}
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return fmt.Errorf("failed to run %s: %w", ec.Command[0], err)
+	}
+
+	message := firstLine(string(output))
+	if message == "" {
+		message = exitErr.Error()
+	}
+
+	if exitErr.ExitCode() == 1 {
+		return fmt.Errorf("%w: %s", ErrDegraded, message)
+	}
+
+	return errors.New(message)
+}
+
+// firstLine returns the first non-empty line of output, shortened to maxExecMessage
+func firstLine(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			if len(line) > maxExecMessage {
+				line = line[:maxExecMessage]
+			}
+			return line
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,31 @@
+package healthcheck
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecChecker_Check(t *testing.T) {
+	ctx := context.Background()
+
+	ok := &ExecChecker{Command: []string{"sh", "-c", "echo OK - all good"}}
+	assert.NoError(t, ok.Check(ctx))
+
+	warning := &ExecChecker{Command: []string{"sh", "-c", "echo 'WARNING - 3 jobs queued'; exit 1"}}
+	err := warning.Check(ctx)
+	assert.ErrorIs(t, err, ErrDegraded)
+	assert.EqualError(t, err, "degraded: WARNING - 3 jobs queued")
+
+	critical := &ExecChecker{Command: []string{"sh", "-c", "printf '\\nCRITICAL - disk full\\nmore\\n'; exit 2"}}
+	assert.EqualError(t, critical.Check(ctx), "CRITICAL - disk full")
+
+	silent := &ExecChecker{Command: []string{"sh", "-c", "exit 3"}}
+	assert.EqualError(t, silent.Check(ctx), "exit status 3")
+
+	missing := &ExecChecker{Command: []string{"/nonexistent/check"}}
+	assert.ErrorContains(t, missing.Check(ctx), "failed to run /nonexistent/check")
+
+	assert.EqualError(t, (&ExecChecker{}).Check(ctx), "missing required field: command")
+}
@@ -0,0 +1,633 @@
+package healthcheck
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"gopkg.in/yaml.v3"
+)
+
+// FileConfig declares the checks of a registry in a YAML or JSON file, e.g.
+//
+//	checks:
+//	  - name: postgresql-sql-connection
+//	    type: postgres
+//	    critical: true
+//	    timeout: 2s
+//	    tags: [database]
+//	  - name: catalog-api
+//	    type: http
+//	    url: https://catalog.internal/health
+//	    interval: 1m
+//	logging:
+//	  level: debug
+type FileConfig struct {
+	Checks []CheckSpec `yaml:"checks"`
+	// Logging configures the logger of the service, it is left as is when not set
+	Logging LoggingSpec `yaml:"logging"`
+
+	// lines holds the line of every check in the file, to point at the bad entries
+	lines []int
+}
+
+// LoggingSpec configures the logger of the service in a FileConfig
+type LoggingSpec struct {
+	// Level is the minimum level logged, e.g. "debug" or "warn", the level is left as is when empty
+	Level string `yaml:"level"`
+}
+
+// CheckSpec declares a single check of a FileConfig.
+// Besides the common fields, a check only accepts the fields of its type.
+type CheckSpec struct {
+	// Name identifies the check, it must be unique
+	Name string `yaml:"name"`
+	// Type is one of postgres, rabbitmq, hazelcast, redis, http, remote, tcp, dns, disk, memory,
+	// file-descriptors, goroutines or exec
+	Type string `yaml:"type"`
+	// Component is the name reported in the response, the name of the built-in check of the type or Name when empty
+	Component string        `yaml:"component"`
+	Version   string        `yaml:"version"`
+	Critical  bool          `yaml:"critical"`
+	Weight    float64       `yaml:"weight"`
+	Interval  time.Duration `yaml:"interval"`
+	Timeout   time.Duration `yaml:"timeout"`
+	Tags      []string      `yaml:"tags"`
+
+	// Deep enables the deep postgres and hazelcast checks
+	Deep bool `yaml:"deep"`
+
+	// postgres
+	ProbeQuery        string        `yaml:"probeQuery"`
+	MaxWaitCount      int64         `yaml:"maxWaitCount"`
+	MaxInUseRatio     float64       `yaml:"maxInUseRatio"`
+	MaxReplicationLag time.Duration `yaml:"maxReplicationLag"`
+	// DegradeOnReadOnly is true when not set, as with the environment configuration
+	DegradeOnReadOnly *bool `yaml:"degradeOnReadOnly"`
+
+	// rabbitmq
+	Queues []QueueThresholds `yaml:"queues"`
+
+	// hazelcast
+	MapName      string        `yaml:"mapName"`
+	TTL          time.Duration `yaml:"ttl"`
+	MaxRoundTrip time.Duration `yaml:"maxRoundTrip"`
+	MinMembers   int           `yaml:"minMembers"`
+
+	// http and remote
+	URL            string            `yaml:"url"`
+	Headers        map[string]string `yaml:"headers"`
+	Method         string            `yaml:"method"`
+	ExpectedStatus []int             `yaml:"expectedStatus"`
+	BodyContains   string            `yaml:"bodyContains"`
+	JSONPath       string            `yaml:"jsonPath"`
+	JSONValue      string            `yaml:"jsonValue"`
+
+	// tcp
+	Address string `yaml:"address"`
+	Banner  string `yaml:"banner"`
+
+	// dns
+	Host       string `yaml:"host"`
+	MinRecords int    `yaml:"minRecords"`
+	Server     string `yaml:"server"`
+
+	// disk
+	Path string `yaml:"path"`
+
+	// Thresholds of the disk, file-descriptors and goroutines checks, their defaults when not set
+	Thresholds *Thresholds `yaml:"thresholds"`
+
+	// memory
+	RSS  Thresholds `yaml:"rss"`
+	Heap Thresholds `yaml:"heap"`
+
+	// exec
+	Command []string `yaml:"command"`
+}
+
+// commonFields are accepted by every type of check
+var commonFields = []string{"name", "type", "component", "version", "critical", "weight", "interval", "timeout", "tags"}
+
+// checkType describes a type of check of a FileConfig
+type checkType struct {
+	// fields are the fields accepted besides the common ones
+	fields []string
+	// deepFields are the fields that only apply with deep: true
+	deepFields []string
+	// component is reported when the check does not set one, the name of the check when empty
+	component string
+	// validate reports the missing or invalid fields of the type
+	validate func(spec *CheckSpec) []error
+	// build creates the checker, failing when the client it needs is not configured
+	build func(spec *CheckSpec, clients *Clients) (Checker, error)
+}
+
+// checkTypes are the types of check of a FileConfig, keyed by type name
+var checkTypes = map[string]checkType{
+	"postgres": {
+		fields: []string{"deep", "probeQuery", "maxWaitCount", "maxInUseRatio", "maxReplicationLag",
+			"degradeOnReadOnly"},
+		deepFields: []string{"probeQuery", "maxWaitCount", "maxInUseRatio", "maxReplicationLag", "degradeOnReadOnly"},
+		component:  "postgresql-sql",
+		validate: func(spec *CheckSpec) []error {
+			var errs []error
+			if spec.MaxWaitCount < 0 {
+				errs = append(errs, errors.New("maxWaitCount must not be negative"))
+			}
+			if spec.MaxInUseRatio < 0 || spec.MaxInUseRatio > 1 {
+				errs = append(errs, errors.New("maxInUseRatio must be between 0 and 1"))
+			}
+			return appendNegative(errs, "maxReplicationLag", spec.MaxReplicationLag)
+		},
+		build: func(spec *CheckSpec, clients *Clients) (Checker, error) {
+			if clients.PgClient == nil {
+				return nil, errors.New("type postgres requires a PostgreSQL connection")
+			}
+
+			var deep *PostgresThresholds
+			if spec.Deep {
+				deep = &PostgresThresholds{
+					ProbeQuery:        spec.ProbeQuery,
+					MaxWaitCount:      spec.MaxWaitCount,
+					MaxInUseRatio:     spec.MaxInUseRatio,
+					MaxReplicationLag: spec.MaxReplicationLag,
+					DegradeOnReadOnly: spec.DegradeOnReadOnly == nil || *spec.DegradeOnReadOnly,
+				}
+			}
+			return NewPostgresChecker(clients.PgClient, deep), nil
+		},
+	},
+	"rabbitmq": {
+		fields:    []string{"queues"},
+		component: "RabbitMQ",
+		validate: func(spec *CheckSpec) []error {
+			var errs []error
+			for i, queue := range spec.Queues {
+				if queue.Name == "" {
+					errs = append(errs, fmt.Errorf("queues[%d]: missing required field: name", i))
+				}
+				if queue.DegradedMessages < 0 || queue.UnhealthyMessages < 0 {
+					errs = append(errs, fmt.Errorf("queues[%d]: message thresholds must not be negative", i))
+				}
+			}
+			return errs
+		},
+		build: func(spec *CheckSpec, clients *Clients) (Checker, error) {
+			if clients.RabbitClient == nil {
+				return nil, errors.New("type rabbitmq requires a RabbitMQ connection")
+			}
+			return &RabbitMQChecker{Client: clients.RabbitClient, Queues: spec.Queues}, nil
+		},
+	},
+	"hazelcast": {
+		fields:     []string{"deep", "mapName", "ttl", "maxRoundTrip", "minMembers"},
+		deepFields: []string{"mapName", "ttl", "maxRoundTrip", "minMembers"},
+		component:  "Hazelcast",
+		validate: func(spec *CheckSpec) []error {
+			var errs []error
+			if spec.MinMembers < 0 {
+				errs = append(errs, errors.New("minMembers must not be negative"))
+			}
+			errs = appendNegative(errs, "ttl", spec.TTL)
+			return appendNegative(errs, "maxRoundTrip", spec.MaxRoundTrip)
+		},
+		build: func(spec *CheckSpec, clients *Clients) (Checker, error) {
+			if clients.HazelcastClient == nil {
+				return nil, errors.New("type hazelcast requires a Hazelcast connection")
+			}
+
+			checker := &HazelcastChecker{Client: clients.HazelcastClient}
+			if spec.Deep {
+				checker.Deep = &HazelcastThresholds{
+					MapName:      spec.MapName,
+					TTL:          spec.TTL,
+					MaxRoundTrip: spec.MaxRoundTrip,
+					MinMembers:   spec.MinMembers,
+				}
+			}
+			return checker, nil
+		},
+	},
+	"redis": {
+		component: "Redis",
+		build: func(_ *CheckSpec, clients *Clients) (Checker, error) {
+			if clients.RedisClient == nil {
+				return nil, errors.New("type redis requires a Redis connection")
+			}
+			return &RedisChecker{Client: clients.RedisClient}, nil
+		},
+	},
+	"http": {
+		fields: []string{"url", "headers", "method", "expectedStatus", "bodyContains", "jsonPath", "jsonValue"},
+		validate: func(spec *CheckSpec) []error {
+			var errs []error
+			if err := validateURL(spec.URL); err != nil {
+				errs = append(errs, err)
+			}
+			for _, code := range spec.ExpectedStatus {
+				if code < 100 || code > 599 {
+					errs = append(errs, fmt.Errorf("expectedStatus %d is not an HTTP status code", code))
+				}
+			}
+			if spec.JSONValue != "" && spec.JSONPath == "" {
+				errs = append(errs, errors.New("jsonValue requires jsonPath"))
+			}
+			return errs
+		},
+		build: func(spec *CheckSpec, clients *Clients) (Checker, error) {
+			return &HTTPChecker{
+				URL:            spec.URL,
+				Method:         spec.Method,
+				Headers:        spec.Headers,
+				ExpectedStatus: spec.ExpectedStatus,
+				BodyContains:   spec.BodyContains,
+				JSONPath:       spec.JSONPath,
+				JSONValue:      spec.JSONValue,
+				TLSConfig:      clients.HTTPTLSConfig,
+			}, nil
+		},
+	},
+	"remote": {
+		fields: []string{"url", "headers"},
+		validate: func(spec *CheckSpec) []error {
+			if err := validateURL(spec.URL); err != nil {
+				return []error{err}
+			}
+			return nil
+		},
+		build: func(spec *CheckSpec, clients *Clients) (Checker, error) {
+			checker := &RemoteChecker{URL: spec.URL, Headers: spec.Headers}
+			if clients.HTTPTLSConfig != nil {
+				transport := http.DefaultTransport.(*http.Transport).Clone()
+				transport.TLSClientConfig = clients.HTTPTLSConfig
+				checker.Client = &http.Client{Transport: transport}
+			}
+			return checker, nil
+		},
+	},
+	"tcp": {
+		fields: []string{"address", "banner"},
+		validate: func(spec *CheckSpec) []error {
+			if err := validateAddress("address", spec.Address, true); err != nil {
+				return []error{err}
+			}
+			return nil
+		},
+		build: func(spec *CheckSpec, _ *Clients) (Checker, error) {
+			return &TCPChecker{Address: spec.Address, Banner: spec.Banner}, nil
+		},
+	},
+	"dns": {
+		fields: []string{"host", "minRecords", "server"},
+		validate: func(spec *CheckSpec) []error {
+			var errs []error
+			if spec.Host == "" {
+				errs = append(errs, errors.New("missing required field: host"))
+			}
+			if spec.MinRecords < 0 {
+				errs = append(errs, errors.New("minRecords must not be negative"))
+			}
+			if err := validateAddress("server", spec.Server, false); err != nil {
+				errs = append(errs, err)
+			}
+			return errs
+		},
+		build: func(spec *CheckSpec, _ *Clients) (Checker, error) {
+			return &DNSChecker{Host: spec.Host, MinRecords: spec.MinRecords, Server: spec.Server}, nil
+		},
+	},
+	"disk": {
+		fields: []string{"path", "thresholds"},
+		validate: func(spec *CheckSpec) []error {
+			var errs []error
+			if spec.Path == "" {
+				errs = append(errs, errors.New("missing required field: path"))
+			}
+			return appendThresholds(errs, "thresholds", spec.Thresholds)
+		},
+		build: func(spec *CheckSpec, _ *Clients) (Checker, error) {
+			return &DiskChecker{Path: spec.Path, Usage: thresholdsOr(spec.Thresholds, DefaultDiskUsage)}, nil
+		},
+	},
+	"memory": {
+		fields: []string{"rss", "heap"},
+		validate: func(spec *CheckSpec) []error {
+			errs := appendThresholds(nil, "rss", &spec.RSS)
+			return appendThresholds(errs, "heap", &spec.Heap)
+		},
+		build: func(spec *CheckSpec, _ *Clients) (Checker, error) {
+			return &MemoryChecker{RSS: spec.RSS, Heap: spec.Heap}, nil
+		},
+	},
+	"file-descriptors": {
+		fields: []string{"thresholds"},
+		validate: func(spec *CheckSpec) []error {
+			return appendThresholds(nil, "thresholds", spec.Thresholds)
+		},
+		build: func(spec *CheckSpec, _ *Clients) (Checker, error) {
+			return &FileDescriptorChecker{Usage: thresholdsOr(spec.Thresholds, DefaultFileDescriptorUsage)}, nil
+		},
+	},
+	"goroutines": {
+		fields: []string{"thresholds"},
+		validate: func(spec *CheckSpec) []error {
+			return appendThresholds(nil, "thresholds", spec.Thresholds)
+		},
+		build: func(spec *CheckSpec, _ *Clients) (Checker, error) {
+			return &GoroutineChecker{Count: thresholdsOr(spec.Thresholds, DefaultGoroutines)}, nil
+		},
+	},
+	"exec": {
+		fields: []string{"command"},
+		validate: func(spec *CheckSpec) []error {
+			if len(spec.Command) == 0 || spec.Command[0] == "" {
+				return []error{errors.New("missing required field: command")}
+			}
+			return nil
+		},
+		build: func(spec *CheckSpec, _ *Clients) (Checker, error) {
+			return &ExecChecker{Command: spec.Command}, nil
+		},
+	},
+}
+
+// LoadFileConfig reads and validates the checks declared in the YAML or JSON file at path
+func LoadFileConfig(path string) (*FileConfig, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the health check configuration: %w", err)
+	}
+
+	return ParseFileConfig(data)
+}
+
+// ParseFileConfig parses and validates checks declared in YAML or in JSON, which YAML is a superset of.
+// The returned error lists every bad entry with its line.
+func ParseFileConfig(data []byte) (*FileConfig, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var config FileConfig
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid health check configuration: %w", err)
+	}
+
+	// Decoded again as a tree for the lines and the fields set by every check
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("invalid health check configuration: %w", err)
+	}
+
+	if err := config.validate(checkNodes(&root)); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// Registry builds a registry holding the declared checks followed by the checks registered in the DefaultRegistry.
+// The postgres, rabbitmq, hazelcast and redis checks use the clients, and fail to build when they are not set.
+func (fc *FileConfig) Registry(clients *Clients) (*Registry, error) {
+	// A configuration built in code has not been validated by ParseFileConfig
+	if fc.lines == nil {
+		if err := fc.validate(nil); err != nil {
+			return nil, err
+		}
+	}
+
+	registry := NewRegistry()
+
+	var errs []error
+	for i := range fc.Checks {
+		spec := &fc.Checks[i]
+		kind := checkTypes[spec.Type]
+
+		checker, err := kind.build(spec, clients)
+		if err != nil {
+			errs = append(errs, fc.entryError(i, err))
+			continue
+		}
+
+		component := spec.Component
+		if component == "" {
+			component = kind.component
+		}
+
+		_ = registry.Register(Config{
+			Name:      spec.Name,
+			Component: component,
+			Version:   spec.Version,
+			Timeout:   spec.Timeout,
+			Critical:  spec.Critical,
+			Weight:    spec.Weight,
+			Interval:  spec.Interval,
+			Tags:      spec.Tags,
+			Checker:   checker,
+		})
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid health check configuration:\n%w", errors.Join(errs...))
+	}
+
+	for _, check := range DefaultRegistry.Checks() {
+		_ = registry.Register(check)
+	}
+
+	return registry, nil
+}
+
+// validate reports every bad entry, nodes are the mapping nodes of the checks in the file
+func (fc *FileConfig) validate(nodes []*yaml.Node) error {
+	if len(fc.Checks) == 0 {
+		return errors.New("invalid health check configuration: no checks declared")
+	}
+
+	fc.lines = make([]int, len(fc.Checks))
+	names := make(map[string]bool, len(fc.Checks))
+
+	var errs []error
+	if _, err := zerolog.ParseLevel(fc.Logging.Level); err != nil {
+		errs = append(errs, fmt.Errorf("logging: unknown level %q", fc.Logging.Level))
+	}
+
+	for i := range fc.Checks {
+		spec := &fc.Checks[i]
+
+		var node *yaml.Node
+		if i < len(nodes) {
+			node = nodes[i]
+			fc.lines[i] = node.Line
+		}
+
+		for _, err := range spec.validate(fieldsOf(node)) {
+			errs = append(errs, fc.entryError(i, err))
+		}
+
+		if spec.Name != "" && names[spec.Name] {
+			errs = append(errs, fc.entryError(i, errors.New("duplicate name")))
+		}
+		names[spec.Name] = true
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid health check configuration:\n%w", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// entryError points err at the i-th check
+func (fc *FileConfig) entryError(i int, err error) error {
+	name := fmt.Sprintf("checks[%d]", i)
+	if fc.Checks[i].Name != "" {
+		name = fmt.Sprintf("%q", fc.Checks[i].Name)
+	}
+
+	return fmt.Errorf("line %d: check %s: %w", fc.lines[i], name, err)
+}
+
+// validate reports the missing, invalid and unsupported fields of the check, fields are the fields it sets
+func (spec *CheckSpec) validate(fields []string) []error {
+	var errs []error
+	if spec.Name == "" {
+		errs = append(errs, errors.New("missing required field: name"))
+	}
+
+	if spec.Weight < 0 {
+		errs = append(errs, errors.New("weight must not be negative"))
+	}
+
+	errs = appendNegative(errs, "interval", spec.Interval)
+	errs = appendNegative(errs, "timeout", spec.Timeout)
+
+	kind, ok := checkTypes[spec.Type]
+	switch {
+	case spec.Type == "":
+		return append(errs, errors.New("missing required field: type"))
+	case !ok:
+		return append(errs, fmt.Errorf("unknown type %q, expected one of %s", spec.Type,
+			strings.Join(slices.Sorted(maps.Keys(checkTypes)), ", ")))
+	}
+
+	for _, field := range fields {
+		if !slices.Contains(commonFields, field) && !slices.Contains(kind.fields, field) {
+			errs = append(errs, fmt.Errorf("field %s is not supported by type %s", field, spec.Type))
+		} else if !spec.Deep && slices.Contains(kind.deepFields, field) {
+			errs = append(errs, fmt.Errorf("field %s requires deep: true", field))
+		}
+	}
+
+	if kind.validate != nil {
+		errs = append(errs, kind.validate(spec)...)
+	}
+
+	return errs
+}
+
+// checkNodes returns the node of every check of the document
+func checkNodes(root *yaml.Node) []*yaml.Node {
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
+		return nil
+	}
+
+	document := root.Content[0]
+	for i := 0; i+1 < len(document.Content); i += 2 {
+		if document.Content[i].Value == "checks" {
+			return document.Content[i+1].Content
+		}
+	}
+
+	return nil
+}
+
+// fieldsOf returns the keys of a mapping node
+func fieldsOf(node *yaml.Node) []string {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	fields := make([]string, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		fields = append(fields, node.Content[i].Value)
+	}
+
+	return fields
+}
+
+// validateURL reports a missing or malformed http(s) URL
+func validateURL(raw string) error {
+	if raw == "" {
+		return errors.New("missing required field: url")
+	}
+
+	parsed, err := url.ParseRequestURI(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("url %q must be an absolute http or https URL", raw)
+	}
+
+	return nil
+}
+
+// validateAddress reports a malformed host:port field, or a missing one when it is required
+func validateAddress(field, address string, required bool) error {
+	if address == "" {
+		if required {
+			return fmt.Errorf("missing required field: %s", field)
+		}
+		return nil
+	}
+
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return fmt.Errorf("%s %q must be host:port: %w", field, address, err)
+	}
+
+	return nil
+}
+
+// appendNegative appends an error when a duration field is negative
+func appendNegative(errs []error, field string, value time.Duration) []error {
+	if value < 0 {
+		return append(errs, fmt.Errorf("%s must not be negative", field))
+	}
+
+	return errs
+}
+
+// appendThresholds appends the errors of a thresholds field that is set
+func appendThresholds(errs []error, field string, thresholds *Thresholds) []error {
+	if thresholds == nil {
+		return errs
+	}
+
+	if thresholds.Warn < 0 || thresholds.Critical < 0 {
+		return append(errs, fmt.Errorf("%s must not be negative", field))
+	}
+
+	if thresholds.Warn > 0 && thresholds.Critical > 0 && thresholds.Warn > thresholds.Critical {
+		return append(errs, fmt.Errorf("%s.warn must not exceed %s.critical", field, field))
+	}
+
+	return errs
+}
+
+// thresholdsOr returns thresholds when they are set, defaults otherwise
+func thresholdsOr(thresholds *Thresholds, defaults Thresholds) Thresholds {
+	if thresholds == nil {
+		return defaults
+	}
+
+	return *thresholds
+}
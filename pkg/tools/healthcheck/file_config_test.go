@@ -0,0 +1,188 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_mockToolsBroker "github.com/samuskitchen/go-health-checker/pkg/tools/mocks/broker"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFileConfig(t *testing.T) {
+	config, err := ParseFileConfig([]byte(`
+checks:
+  - name: rabbitmq-connection
+    type: rabbitmq
+    critical: true
+    timeout: 2s
+    tags: [messaging]
+    queues:
+      - name: beers
+        degradedMessages: 100
+  - name: catalog-api
+    type: http
+    component: Catalog
+    version: "2.1"
+    url: https://catalog.internal/health
+    expectedStatus: [200, 204]
+    interval: 1m
+    weight: 0.5
+  - name: data-disk
+    type: disk
+    path: /
+    thresholds: {warn: 70}
+logging:
+  level: debug
+`))
+	assert.NoError(t, err)
+	assert.Len(t, config.Checks, 3)
+	assert.Equal(t, 2*time.Second, config.Checks[0].Timeout)
+	assert.Equal(t, []QueueThresholds{{Name: "beers", DegradedMessages: 100}}, config.Checks[0].Queues)
+	assert.Equal(t, time.Minute, config.Checks[1].Interval)
+	assert.Equal(t, "debug", config.Logging.Level)
+
+	mockBroker := _mockToolsBroker.NewMockClient(t)
+	registry, err := config.Registry(&Clients{RabbitClient: mockBroker})
+	assert.NoError(t, err)
+
+	checks := registry.Checks()
+	assert.Len(t, checks, 3)
+	assert.Equal(t, "RabbitMQ", checks[0].Component, "the component of the built-in check is the default")
+	assert.True(t, checks[0].Critical)
+	assert.Equal(t, []string{"messaging"}, checks[0].Tags)
+	assert.Equal(t, "Catalog", checks[1].Component)
+	assert.Equal(t, "2.1", checks[1].Version)
+	assert.Equal(t, 0.5, checks[1].Weight)
+	assert.Equal(t, []int{200, 204}, checks[1].Checker.(*HTTPChecker).ExpectedStatus)
+	assert.Equal(t, Thresholds{Warn: 70}, checks[2].Checker.(*DiskChecker).Usage)
+}
+
+func TestParseFileConfig_JSON(t *testing.T) {
+	config, err := ParseFileConfig([]byte(`{"checks": [
+		{"name": "smtp-relay", "type": "tcp", "address": "smtp.internal:25", "banner": "220"},
+		{"name": "backup", "type": "exec", "command": ["/usr/lib/nagios/check_backup", "-w", "24h"]}
+	]}`))
+	assert.NoError(t, err)
+
+	registry, err := config.Registry(&Clients{})
+	assert.NoError(t, err)
+	assert.Equal(t, &TCPChecker{Address: "smtp.internal:25", Banner: "220"}, registry.Checks()[0].Checker)
+	assert.Equal(t, []string{"/usr/lib/nagios/check_backup", "-w", "24h"},
+		registry.Checks()[1].Checker.(*ExecChecker).Command)
+}
+
+func TestParseFileConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		expected []string
+	}{
+		{
+			name:     "empty",
+			config:   ``,
+			expected: []string{"no checks declared"},
+		},
+		{
+			name:     "unknown field",
+			config:   "checks:\n  - name: catalog-api\n    type: http\n    urll: https://catalog.internal\n",
+			expected: []string{"line 4: field urll not found"},
+		},
+		{
+			name:     "unknown logging level",
+			config:   "checks:\n  - name: goroutines\n    type: goroutines\nlogging:\n  level: verbose\n",
+			expected: []string{`logging: unknown level "verbose"`},
+		},
+		{
+			name: "bad entries",
+			config: `checks:
+  - type: http
+    url: ftp://catalog.internal
+  - name: smtp-relay
+    type: tcp
+    url: https://smtp.internal
+  - name: smtp-relay
+    type: smtp
+  - name: database
+    type: postgres
+    maxInUseRatio: 2
+  - name: data-disk
+    type: disk
+    timeout: -1s
+    thresholds: {warn: 95, critical: 80}
+`,
+			expected: []string{
+				`line 2: check checks[0]: missing required field: name`,
+				`line 2: check checks[0]: url "ftp://catalog.internal" must be an absolute http or https URL`,
+				`line 4: check "smtp-relay": field url is not supported by type tcp`,
+				`line 4: check "smtp-relay": missing required field: address`,
+				`line 7: check "smtp-relay": unknown type "smtp", expected one of disk, dns, exec,`,
+				`line 7: check "smtp-relay": duplicate name`,
+				`line 9: check "database": field maxInUseRatio requires deep: true`,
+				`line 9: check "database": maxInUseRatio must be between 0 and 1`,
+				`line 12: check "data-disk": timeout must not be negative`,
+				`line 12: check "data-disk": missing required field: path`,
+				`line 12: check "data-disk": thresholds.warn must not exceed thresholds.critical`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFileConfig([]byte(tt.config))
+			assert.Error(t, err)
+			for _, expected := range tt.expected {
+				assert.ErrorContains(t, err, expected)
+			}
+		})
+	}
+}
+
+func TestFileConfig_Registry_MissingClient(t *testing.T) {
+	config, err := ParseFileConfig([]byte("checks:\n  - name: cache\n    type: redis\n"))
+	assert.NoError(t, err)
+
+	_, err = config.Registry(&Clients{})
+	assert.ErrorContains(t, err, `line 2: check "cache": type redis requires a Redis connection`)
+}
+
+func TestFileConfig_Registry_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"overallStatus": "Available"}`))
+	}))
+	defer server.Close()
+
+	config, err := ParseFileConfig([]byte(fmt.Sprintf(
+		"checks:\n  - name: catalog\n    type: http\n    url: %[1]s\n  - name: orders\n    type: remote\n    url: %[1]s\n",
+		server.URL)))
+	assert.NoError(t, err)
+
+	tlsConfig := server.Client().Transport.(*http.Transport).TLSClientConfig
+	registry, err := config.Registry(&Clients{HTTPTLSConfig: tlsConfig})
+	assert.NoError(t, err)
+
+	for _, check := range registry.Checks() {
+		assert.NoError(t, check.Checker.Check(context.Background()), "%s trusts the CAs of the clients", check.Name)
+	}
+}
+
+func TestLoadFileConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "health.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("checks:\n  - name: goroutines\n    type: goroutines\n"), 0o600))
+
+	config, err := LoadFileConfig(path)
+	assert.NoError(t, err)
+
+	registry, err := config.Registry(&Clients{})
+	assert.NoError(t, err)
+	assert.Equal(t, StatusAvailable, registry.CheckerHealth(context.Background()).OverallStatus)
+
+	_, err = LoadFileConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read the health check configuration")
+}
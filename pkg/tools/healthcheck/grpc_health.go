@@ -0,0 +1,70 @@
+package healthcheck
+
+import (
+	"sync"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// GRPCHealth publishes the results of the health checks through the standard grpc.health.v1.Health service:
+// the overall status under the empty service name and the status of every check under its component name.
+// Watch streams a new status whenever a run flips the overall status or a component.
+type GRPCHealth struct {
+	server *health.Server
+
+	mu sync.Mutex
+	// components are the components published by the previous run
+	components map[string]bool
+}
+
+// NewGRPCHealth creates a gRPC health service that reports NOT_SERVING until it observes a first run
+func NewGRPCHealth() *GRPCHealth {
+	server := health.NewServer()
+	server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	return &GRPCHealth{server: server}
+}
+
+// Server returns the gRPC health service to register on a gRPC server
+func (g *GRPCHealth) Server() healthpb.HealthServer {
+	return g.server
+}
+
+// ObserveHealth updates the overall status and the status of every check of resp.
+// A degraded check or overall status is still serving. The components that are no longer checked,
+// e.g. after a reload, are reported as SERVICE_UNKNOWN.
+func (g *GRPCHealth) ObserveHealth(resp Response) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.server.SetServingStatus("", servingStatus(resp.OverallStatus == StatusAvailable || resp.OverallStatus.passed()))
+
+	components := make(map[string]bool, len(resp.Checks))
+	for _, check := range resp.Checks {
+		components[check.Component] = true
+		g.server.SetServingStatus(check.Component, servingStatus(check.Status.passed()))
+	}
+
+	for component := range g.components {
+		if !components[component] {
+			g.server.SetServingStatus(component, healthpb.HealthCheckResponse_SERVICE_UNKNOWN)
+		}
+	}
+
+	g.components = components
+}
+
+// Shutdown reports every service as NOT_SERVING and ignores later runs, e.g. while the server drains
+func (g *GRPCHealth) Shutdown() {
+	g.server.Shutdown()
+}
+
+// servingStatus converts whether a check passed to its gRPC serving status
+func servingStatus(passed bool) healthpb.HealthCheckResponse_ServingStatus {
+	if passed {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
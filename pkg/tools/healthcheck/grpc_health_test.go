@@ -0,0 +1,81 @@
+package healthcheck
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestGRPCHealth(t *testing.T) {
+	grpcHealth := NewGRPCHealth()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, grpcHealth.Server())
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	client := healthpb.NewHealthClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	check := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, errCheck := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		assert.NoError(t, errCheck)
+		return resp.GetStatus()
+	}
+
+	// Nothing has been checked yet
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check(""))
+
+	grpcHealth.ObserveHealth(Response{
+		OverallStatus: StatusDegraded,
+		Checks: []Health{
+			{Component: "RabbitMQ", Status: StatusOK},
+			{Component: "Hazelcast", Status: StatusUnavailable},
+		},
+	})
+
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check("RabbitMQ"))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check("Hazelcast"))
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "Hazelcast"})
+	assert.NoError(t, err)
+
+	update, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, update.GetStatus())
+
+	// A background run flips the component
+	grpcHealth.ObserveHealth(Response{
+		OverallStatus: StatusAvailable,
+		Checks:        []Health{{Component: "Hazelcast", Status: StatusOK}},
+	})
+
+	update, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, update.GetStatus())
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVICE_UNKNOWN, check("RabbitMQ"), "a removed check is unknown")
+
+	grpcHealth.Shutdown()
+
+	update, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, update.GetStatus())
+}
@@ -0,0 +1,97 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/samuskitchen/go-health-checker/pkg/tools/datastore"
+)
+
+// Defaults of the deep Hazelcast check
+const (
+	// DefaultHazelcastHealthMap is the map used for the round trip when none is configured
+	DefaultHazelcastHealthMap = "health-check"
+	// DefaultHazelcastHealthTTL is the TTL of the round trip entry when none is configured
+	DefaultHazelcastHealthTTL = 30 * time.Second
+)
+
+// HazelcastThresholds configures the deep Hazelcast check. A zero threshold disables it.
+type HazelcastThresholds struct {
+	// MapName is the dedicated map of the round trip, DefaultHazelcastHealthMap when empty
+	MapName string
+	// TTL expires the round trip entry if it is never deleted, DefaultHazelcastHealthTTL when zero
+	TTL time.Duration
+	// MaxRoundTrip degrades the check when the put/get/delete round trip is slower than this
+	MaxRoundTrip time.Duration
+	// MinMembers fails the check when the cluster has fewer members than this
+	MinMembers int
+}
+
+// HazelcastChecker checks the Hazelcast client connection
+type HazelcastChecker struct {
+	Client datastore.IClient
+	// Deep enables the map round trip and the membership check, only the client state is checked when nil.
+	// It requires a Client that implements datastore.ICacheProbe.
+	Deep *HazelcastThresholds
+}
+
+// Check performs a health check on a Hazelcast client
+func (hc *HazelcastChecker) Check(ctx context.Context) error {
+	_, err := hc.CheckDetails(ctx)
+	return err
+}
+
+// CheckDetails performs a health check on a Hazelcast client.
+// In deep mode it also reports the latency of a map round trip and the number of members of the cluster,
+// failing the check when the round trip fails or there are too few members and degrading it when it is slow.
+func (hc *HazelcastChecker) CheckDetails(ctx context.Context) (map[string]any, error) {
+	if err := hc.Client.Ping(); err != nil {
+		return nil, err
+	}
+
+	if hc.Deep == nil {
+		return nil, nil
+	}
+
+	probe, ok := hc.Client.(datastore.ICacheProbe)
+	if !ok {
+		return nil, fmt.Errorf("the cache client does not support the deep check")
+	}
+
+	mapName := hc.Deep.MapName
+	if mapName == "" {
+		mapName = DefaultHazelcastHealthMap
+	}
+
+	ttl := hc.Deep.TTL
+	if ttl <= 0 {
+		ttl = DefaultHazelcastHealthTTL
+	}
+
+	members := probe.MemberCount()
+	details := map[string]any{"members": members}
+
+	start := time.Now()
+	if err := probe.RoundTrip(ctx, mapName, ttl); err != nil {
+		return details, err
+	}
+
+	roundTrip := time.Since(start)
+	details["roundTripMs"] = float64(roundTrip.Microseconds()) / 1000
+
+	if hc.Deep.MinMembers > 0 && members < hc.Deep.MinMembers {
+		return details, fmt.Errorf("cluster has %d members, at least %d are required", members, hc.Deep.MinMembers)
+	}
+
+	if hc.Deep.MaxRoundTrip > 0 && roundTrip > hc.Deep.MaxRoundTrip {
+		return details, fmt.Errorf("%w: map round trip took %v", ErrDegraded, roundTrip.Round(time.Millisecond))
+	}
+
+	return details, nil
+}
+
+// Version returns the version of the Hazelcast cluster
+func (hc *HazelcastChecker) Version(_ context.Context) (string, error) {
+	return hc.Client.ServerVersion()
+}
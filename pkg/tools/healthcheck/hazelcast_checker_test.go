@@ -0,0 +1,107 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	_mockToolsDataStore "github.com/samuskitchen/go-health-checker/pkg/tools/mocks/datastore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// hazelcastClient is a cache client that supports the deep check
+type hazelcastClient struct {
+	*_mockToolsDataStore.MockIClient
+	*_mockToolsDataStore.MockICacheProbe
+}
+
+func newHazelcastClient(t *testing.T) hazelcastClient {
+	return hazelcastClient{
+		MockIClient:     _mockToolsDataStore.NewMockIClient(t),
+		MockICacheProbe: _mockToolsDataStore.NewMockICacheProbe(t),
+	}
+}
+
+func TestHazelcastChecker_CheckDetails(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("client state only", func(t *testing.T) {
+		client := _mockToolsDataStore.NewMockIClient(t)
+		client.On("Ping").Return(nil)
+
+		details, err := (&HazelcastChecker{Client: client}).CheckDetails(ctx)
+
+		assert.NoError(t, err)
+		assert.Nil(t, details)
+	})
+
+	t.Run("healthy", func(t *testing.T) {
+		client := newHazelcastClient(t)
+		client.MockIClient.On("Ping").Return(nil)
+		client.MockICacheProbe.On("MemberCount").Return(3)
+		client.MockICacheProbe.On("RoundTrip", mock.Anything, DefaultHazelcastHealthMap, DefaultHazelcastHealthTTL).Return(nil)
+
+		checker := &HazelcastChecker{Client: client, Deep: &HazelcastThresholds{MinMembers: 2, MaxRoundTrip: time.Second}}
+		details, err := checker.CheckDetails(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, details["members"])
+		assert.Contains(t, details, "roundTripMs")
+	})
+
+	t.Run("slow round trip", func(t *testing.T) {
+		client := newHazelcastClient(t)
+		client.MockIClient.On("Ping").Return(nil)
+		client.MockICacheProbe.On("MemberCount").Return(3)
+		client.MockICacheProbe.On("RoundTrip", mock.Anything, "health", time.Minute).
+			Run(func(mock.Arguments) { time.Sleep(20 * time.Millisecond) }).
+			Return(nil)
+
+		checker := &HazelcastChecker{Client: client, Deep: &HazelcastThresholds{
+			MapName:      "health",
+			TTL:          time.Minute,
+			MaxRoundTrip: time.Millisecond,
+		}}
+		_, err := checker.CheckDetails(ctx)
+
+		assert.ErrorIs(t, err, ErrDegraded)
+		assert.Contains(t, err.Error(), "map round trip took")
+	})
+
+	t.Run("too few members", func(t *testing.T) {
+		client := newHazelcastClient(t)
+		client.MockIClient.On("Ping").Return(nil)
+		client.MockICacheProbe.On("MemberCount").Return(1)
+		client.MockICacheProbe.On("RoundTrip", mock.Anything, DefaultHazelcastHealthMap, DefaultHazelcastHealthTTL).Return(nil)
+
+		_, err := (&HazelcastChecker{Client: client, Deep: &HazelcastThresholds{MinMembers: 2}}).CheckDetails(ctx)
+
+		assert.EqualError(t, err, "cluster has 1 members, at least 2 are required")
+	})
+
+	t.Run("round trip failing", func(t *testing.T) {
+		client := newHazelcastClient(t)
+		client.MockIClient.On("Ping").Return(nil)
+		client.MockICacheProbe.On("MemberCount").Return(3)
+		client.MockICacheProbe.On("RoundTrip", mock.Anything, DefaultHazelcastHealthMap, DefaultHazelcastHealthTTL).
+			Return(errors.New("failed to put the round trip entry: partition lost"))
+
+		details, err := (&HazelcastChecker{Client: client, Deep: &HazelcastThresholds{}}).CheckDetails(ctx)
+
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrDegraded)
+		assert.Equal(t, 3, details["members"])
+	})
+
+	t.Run("deep check without probe", func(t *testing.T) {
+		client := _mockToolsDataStore.NewMockIClient(t)
+		client.On("Ping").Return(nil)
+
+		_, err := (&HazelcastChecker{Client: client, Deep: &HazelcastThresholds{}}).CheckDetails(ctx)
+
+		assert.EqualError(t, err, "the cache client does not support the deep check")
+	})
+}
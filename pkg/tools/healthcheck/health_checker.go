@@ -3,178 +3,454 @@ package healthcheck
 
 import (
 	"context"
+	"crypto/tls"
 	"database/sql"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
-	"github.com/hellofresh/health-go/v5"
 	"github.com/samuskitchen/go-health-checker/pkg/tools/broker"
 	"github.com/samuskitchen/go-health-checker/pkg/tools/datastore"
 )
 
+// DefaultTimeout is the timeout applied to a check that does not configure its own
+const DefaultTimeout = 5 * time.Second
+
 // Clients represent the clients to be checked
 type Clients struct {
 	RabbitClient    broker.Client
 	HazelcastClient datastore.IClient
+	RedisClient     datastore.IClient
 	PgClient        *sql.DB
+	// Timeouts overrides the timeout of the built-in checks, keyed by check name
+	Timeouts map[string]time.Duration
+	// Critical marks the built-in checks the service cannot work without, keyed by check name
+	Critical map[string]bool
+	// Weights overrides the weight of the built-in checks, keyed by check name
+	Weights map[string]float64
+	// PostgresDeep enables the deep PostgresSQL check, only a ping is made when nil
+	PostgresDeep *PostgresThresholds
+	// RabbitQueues are the queues inspected by the RabbitMQ check
+	RabbitQueues []QueueThresholds
+	// HazelcastDeep enables the deep Hazelcast check, only the client state is checked when nil
+	HazelcastDeep *HazelcastThresholds
+	// HTTP are the upstream HTTP dependencies, keyed by check name
+	HTTP map[string]*HTTPChecker
+	// HTTPTLSConfig is applied to the http and remote checks of a FileConfig, e.g. to trust a private CA
+	HTTPTLSConfig *tls.Config
+	// TCP are the TCP endpoints that must accept connections, keyed by check name
+	TCP map[string]*TCPChecker
+	// DNS are the hostnames that must resolve, keyed by check name
+	DNS map[string]*DNSChecker
+	// Disks are the filesystems whose free space is checked, keyed by check name
+	Disks map[string]*DiskChecker
+	// Resources enables the memory, file descriptors and goroutines checks of the process
+	Resources *ResourceThresholds
 }
 
 // Response represents the health check response
 type Response struct {
-	OverallStatus string   `json:"overallStatus"`
-	Timestamp     string   `json:"timestamp"`
-	Checks        []Health `json:"checks"`
+	OverallStatus Status `json:"overallStatus"`
+	// Score is the weighted share of passing checks, from 0 to 1
+	Score     float64  `json:"score"`
+	Timestamp string   `json:"timestamp"`
+	Checks    []Health `json:"checks"`
 }
 
 // Health represents the health check response
 type Health struct {
-	Status    string `json:"status"`
+	Status    Status `json:"status"`
 	Component string `json:"component"`
-	Version   string `json:"version"`
+	Version   string `json:"version,omitempty"`
+	Critical  bool   `json:"critical"`
+	// Tags group the checks, e.g. "database"
+	Tags []string `json:"tags,omitempty"`
+	// DurationMs is how long the check took in milliseconds
+	DurationMs float64 `json:"durationMs,omitempty"`
+	// Error is the reason the check failed or is degraded
+	Error string `json:"error,omitempty"`
+	// Details holds what a DetailedChecker reported about its dependency
+	Details             map[string]any `json:"details,omitempty"`
+	LastSuccess         *time.Time     `json:"lastSuccess,omitempty"`
+	LastFailure         *time.Time     `json:"lastFailure,omitempty"`
+	ConsecutiveFailures int            `json:"consecutiveFailures,omitempty"`
+	weight              float64
+	// cached reports a result reused from a previous run because the interval of the check has not elapsed
+	cached bool
 }
 
-// CheckerHealth performs a health check on all clients
+// CheckerHealth performs a health check on all clients and on every check of the DefaultRegistry
 func (cl *Clients) CheckerHealth(ctx context.Context) Response {
-	var checks []Health
+	return cl.Registry().CheckerHealth(ctx)
+}
 
-	// Collect Health Checks from all clients
-	cl.collectRabbitMQChecks(ctx, &checks)
-	cl.collectHazelcastChecks(ctx, &checks)
-	cl.collectPostgresSQLChecks(ctx, &checks)
+// Registry builds a registry holding the checks of the configured clients
+// followed by the checks registered in the DefaultRegistry
+func (cl *Clients) Registry() *Registry {
+	registry := NewRegistry()
 
-	// Calculate Overall Status based on the number of OK checks
-	overallStatus := calculateOverallStatus(checks)
+	for _, check := range cl.builtinChecks() {
+		check.Timeout = cl.Timeouts[check.Name]
+		check.Critical = cl.Critical[check.Name]
+		check.Weight = cl.Weights[check.Name]
+		_ = registry.Register(check)
+	}
 
-	return Response{
-		OverallStatus: overallStatus,
-		Timestamp:     time.Now().Format(time.RFC3339),
-		Checks:        checks,
+	for _, check := range DefaultRegistry.Checks() {
+		_ = registry.Register(check)
 	}
+
+	return registry
 }
 
-// collectRabbitMQChecks collects health checks for RabbitMQ client
-func (cl *Clients) collectRabbitMQChecks(ctx context.Context, checks *[]Health) {
-	if check := cl.checkRabbitMQ(ctx); check != nil {
-		*checks = append(*checks, *check)
+// builtinChecks returns the checks for the clients that are set
+func (cl *Clients) builtinChecks() []Config {
+	var checks []Config
+
+	if cl.RabbitClient != nil {
+		checks = append(checks, Config{
+			Name:      "rabbitmq-connection",
+			Component: "RabbitMQ",
+			Checker:   &RabbitMQChecker{Client: cl.RabbitClient, Queues: cl.RabbitQueues},
+		})
+	}
+
+	if cl.HazelcastClient != nil {
+		checks = append(checks, Config{
+			Name:      "hazelcast-connection",
+			Component: "Hazelcast",
+			Checker:   &HazelcastChecker{Client: cl.HazelcastClient, Deep: cl.HazelcastDeep},
+		})
+	}
+
+	if cl.RedisClient != nil {
+		checks = append(checks, Config{
+			Name:      "redis-connection",
+			Component: "Redis",
+			Checker:   &RedisChecker{Client: cl.RedisClient},
+		})
 	}
+
+	if cl.PgClient != nil {
+		checks = append(checks, Config{
+			Name:      "postgresql-sql-connection",
+			Component: "postgresql-sql",
+			Checker:   NewPostgresChecker(cl.PgClient, cl.PostgresDeep),
+		})
+	}
+
+	checks = appendNamedChecks(checks, cl.HTTP)
+	checks = appendNamedChecks(checks, cl.TCP)
+	checks = appendNamedChecks(checks, cl.DNS)
+	checks = appendNamedChecks(checks, cl.Disks)
+
+	if cl.Resources != nil {
+		checks = append(checks,
+			Config{
+				Name:    "process-memory",
+				Checker: &MemoryChecker{RSS: cl.Resources.RSS, Heap: cl.Resources.Heap},
+			},
+			Config{
+				Name:    "file-descriptors",
+				Checker: &FileDescriptorChecker{Usage: cl.Resources.FileDescriptors},
+			},
+			Config{
+				Name:    "goroutines",
+				Checker: &GoroutineChecker{Count: cl.Resources.Goroutines},
+			},
+		)
+	}
+
+	return checks
 }
 
-// collectHazelcastChecks collects health checks for a Hazelcast client
-func (cl *Clients) collectHazelcastChecks(ctx context.Context, checks *[]Health) {
-	if check := cl.checkHazelcast(ctx); check != nil {
-		*checks = append(*checks, *check)
+// appendNamedChecks appends a check for each checker, sorted by name
+func appendNamedChecks[T Checker](checks []Config, checkers map[string]T) []Config {
+	for _, name := range slices.Sorted(maps.Keys(checkers)) {
+		checks = append(checks, Config{
+			Name:    name,
+			Checker: checkers[name],
+		})
 	}
+
+	return checks
 }
 
-// collectPostgresSQLChecks collects health checks for PostgresSQL database/sql client
-func (cl *Clients) collectPostgresSQLChecks(ctx context.Context, checks *[]Health) {
-	if check := cl.checkPostgresSQL(ctx); check != nil {
-		*checks = append(*checks, *check)
+// CheckerHealth performs every registered check concurrently and builds the response.
+// A check whose interval has not elapsed since its previous run reports that run again.
+// Each check is bounded by its own timeout and by the deadline of ctx, whichever comes first;
+// checks that do not finish in time are reported with a timeout status.
+func (r *Registry) CheckerHealth(ctx context.Context) Response {
+	configs := r.Checks()
+	checks := make([]Health, len(configs))
+
+	var wg sync.WaitGroup
+	for i, cfg := range configs {
+		wg.Add(1)
+		go func(i int, cfg Config) {
+			defer wg.Done()
+			if cached, ok := r.cached(cfg, time.Now()); ok {
+				checks[i] = cached
+				return
+			}
+			checks[i] = measure(ctx, cfg)
+			r.record(cfg.Name, &checks[i], time.Now())
+		}(i, cfg)
+	}
+	wg.Wait()
+
+	// Calculate Overall Status based on the criticality and weight of the failing checks
+	overallStatus, score := calculateOverallStatus(checks)
+
+	return Response{
+		OverallStatus: overallStatus,
+		Score:         score,
+		Timestamp:     time.Now().Format(time.RFC3339),
+		Checks:        checks,
 	}
 }
 
-// checkRabbitMQ performs a health check on RabbitMQ
-func (cl *Clients) checkRabbitMQ(ctx context.Context) *Health {
-	if cl.RabbitClient == nil {
-		return nil
+// measure runs a single check, giving up when its timeout or the deadline of ctx expires
+func measure(ctx context.Context, cfg Config) Health {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
 	}
 
-	h, _ := health.New(
-		health.WithComponent(health.Component{Name: "RabbitMQ", Version: "1.0.0"}),
-		health.WithChecks(health.Config{
-			Name:      "rabbitmq-connection",
-			Timeout:   time.Second * 5,
-			SkipOnErr: true,
-			Check: func(_ context.Context) error {
-				return cl.RabbitClient.Ping()
-			},
-		}),
-	)
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Buffered so the checker goroutine never blocks if we stop waiting for it
+	result := make(chan checkResult, 1)
+	start := time.Now()
+	go func() {
+		result <- runCheck(checkCtx, cfg)
+	}()
+
+	status := StatusOK
+	version := cfg.Version
+	var details map[string]any
+	var checkErr error
+	select {
+	case res := <-result:
+		checkErr = res.err
+		details = res.details
+		if errors.Is(checkErr, ErrDegraded) {
+			status = StatusDegraded
+		} else if checkErr != nil {
+			status = StatusUnavailable
+		}
+		if res.version != "" {
+			version = res.version
+		}
+	case <-checkCtx.Done():
+		status = StatusTimeout
+		checkErr = fmt.Errorf("check did not finish in time: %w", checkCtx.Err())
+	}
+
+	elapsed := time.Since(start)
+
+	weight := cfg.Weight
+	if weight <= 0 {
+		weight = 1
+	}
+
+	check := Health{
+		Status:     status,
+		Component:  cfg.Component,
+		Version:    version,
+		Critical:   cfg.Critical,
+		Tags:       cfg.Tags,
+		DurationMs: float64(elapsed.Microseconds()) / 1000,
+		Details:    details,
+		weight:     weight,
+	}
 
-	data := h.Measure(ctx)
-	return &Health{
-		Status:    string(data.Status),
-		Component: data.Name,
-		Version:   data.Component.Version,
+	if checkErr != nil {
+		check.Error = checkErr.Error()
 	}
+
+	return check
+}
+
+// checkResult is the outcome of a single run of a checker
+type checkResult struct {
+	version string
+	details map[string]any
+	err     error
 }
 
-// checkHazelcast performs a health check on a Hazelcast client
-func (cl *Clients) checkHazelcast(ctx context.Context) *Health {
-	if cl.HazelcastClient == nil {
-		return nil
+// runCheck runs the checker and, when its dependency answers, looks up the version of the dependency
+func runCheck(ctx context.Context, cfg Config) checkResult {
+	var res checkResult
+	if detailedChecker, ok := cfg.Checker.(DetailedChecker); ok {
+		res.details, res.err = detailedChecker.CheckDetails(ctx)
+	} else {
+		res.err = cfg.Checker.Check(ctx)
 	}
 
-	h, _ := health.New(
-		health.WithComponent(health.Component{Name: "Hazelcast", Version: "1.0.0"}),
-		health.WithChecks(health.Config{
-			Name:      "hazelcast-connection",
-			Timeout:   time.Second * 5,
-			SkipOnErr: true,
-			Check: func(_ context.Context) error {
-				return cl.HazelcastClient.Ping()
-			},
-		}),
-	)
+	if res.err != nil && !errors.Is(res.err, ErrDegraded) {
+		return res
+	}
 
-	data := h.Measure(ctx)
-	return &Health{
-		Status:    string(data.Status),
-		Component: data.Name,
-		Version:   data.Component.Version,
+	versionChecker, ok := cfg.Checker.(VersionChecker)
+	if !ok {
+		return res
 	}
+
+	// A failed lookup does not fail the check, the configured version is reported instead
+	res.version, _ = versionChecker.Version(ctx)
+	return res
+}
+
+// ParseTimeouts parses a comma separated list of name=duration pairs,
+// e.g. "postgresql-sql-connection=2s,rabbitmq-connection=500ms"
+func ParseTimeouts(value string) (map[string]time.Duration, error) {
+	return parsePairs(value, time.ParseDuration)
+}
+
+// ParseWeights parses a comma separated list of name=weight pairs,
+// e.g. "postgresql-sql-connection=3,hazelcast-connection=0.5"
+func ParseWeights(value string) (map[string]float64, error) {
+	return parsePairs(value, func(raw string) (float64, error) {
+		weight, err := strconv.ParseFloat(raw, 64)
+		if err == nil && weight < 0 {
+			return 0, fmt.Errorf("weight must not be negative")
+		}
+		return weight, err
+	})
 }
 
-// checkPostgresSQL performs a health check for PostgresSQL database/sql client
-func (cl *Clients) checkPostgresSQL(ctx context.Context) *Health {
-	if cl.PgClient == nil {
-		return nil
+// ParseCounts parses a comma separated list of name=count pairs,
+// e.g. "beers=100,orders=1000"
+func ParseCounts(value string) (map[string]int, error) {
+	return parsePairs(value, func(raw string) (int, error) {
+		count, err := strconv.Atoi(raw)
+		if err == nil && count < 0 {
+			return 0, fmt.Errorf("count must not be negative")
+		}
+		return count, err
+	})
+}
+
+// ParseURLs parses a comma separated list of name=url pairs,
+// e.g. "catalog-api=https://catalog.internal/health"
+func ParseURLs(value string) (map[string]string, error) {
+	return parsePairs(value, func(raw string) (string, error) {
+		if _, err := url.ParseRequestURI(raw); err != nil {
+			return "", err
+		}
+		return raw, nil
+	})
+}
+
+// ParseAddresses parses a comma separated list of name=host:port pairs,
+// e.g. "smtp-relay=smtp.internal:25"
+func ParseAddresses(value string) (map[string]string, error) {
+	return parsePairs(value, func(raw string) (string, error) {
+		if _, _, err := net.SplitHostPort(raw); err != nil {
+			return "", err
+		}
+		return raw, nil
+	})
+}
+
+// ParseStrings parses a comma separated list of name=value pairs,
+// e.g. "catalog-dns=catalog.internal"
+func ParseStrings(value string) (map[string]string, error) {
+	return parsePairs(value, func(raw string) (string, error) {
+		return raw, nil
+	})
+}
+
+// ParseNames parses a comma separated list of check names into a set,
+// e.g. "postgresql-sql-connection,rabbitmq-connection"
+func ParseNames(value string) map[string]bool {
+	names := map[string]bool{}
+
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names[name] = true
+		}
 	}
 
-	h, _ := health.New(
-		health.WithComponent(health.Component{Name: "postgresql-sql", Version: "1.0.0"}),
-		health.WithChecks(health.Config{
-			Name:      "postgresql-sql-connection",
-			Timeout:   time.Second * 5,
-			SkipOnErr: true,
-			Check: func(ctx context.Context) error {
-				return cl.PgClient.PingContext(ctx)
-			},
-		}),
-	)
+	return names
+}
 
-	data := h.Measure(ctx)
-	return &Health{
-		Status:    string(data.Status),
-		Component: data.Name,
-		Version:   data.Component.Version,
+// parsePairs parses a comma separated list of name=value pairs with the given value parser
+func parsePairs[T any](value string, parse func(string) (T, error)) (map[string]T, error) {
+	pairs := map[string]T{}
+
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		name, raw, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid pair %q: expected name=value", pair)
+		}
+
+		parsed, err := parse(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %q: %w", name, err)
+		}
+
+		pairs[strings.TrimSpace(name)] = parsed
 	}
+
+	return pairs, nil
 }
 
-// calculateOverallStatus calculates the overall status of the checks based on the number of OK checks
-func calculateOverallStatus(checks []Health) string {
+// calculateOverallStatus calculates the overall status and the health score of the checks.
+// A failing critical check makes the service Unavailable, as does every check failing;
+// failing optional checks and degraded checks only make it Degraded.
+// A degraded check counts for half its weight in the score.
+func calculateOverallStatus(checks []Health) (Status, float64) {
 	if len(checks) == 0 {
-		return "unknown"
+		return StatusUnknown, 0
 	}
 
-	okCount := 0
-	totalCount := len(checks)
+	var okWeight, totalWeight float64
+	criticalFailed := false
 
 	for _, check := range checks {
-		if check.Status == "OK" {
-			okCount++
+		totalWeight += check.weight
+
+		switch check.Status {
+		case StatusOK:
+			okWeight += check.weight
+			continue
+		case StatusDegraded:
+			okWeight += check.weight / 2
+			continue
+		}
+
+		if check.Critical {
+			criticalFailed = true
 		}
 	}
 
+	score := okWeight / totalWeight
+
 	// All checks are OK
-	if okCount == totalCount {
-		return "Available"
+	if okWeight == totalWeight {
+		return StatusAvailable, score
 	}
 
-	// Not all checks are OK
-	if okCount == 0 {
-		return "Unavailable"
+	// A dependency the service cannot work without is down, or nothing works at all
+	if criticalFailed || okWeight == 0 {
+		return StatusUnavailable, score
 	}
 
-	// Some checks are OK, some are not
-	return "Partially Available"
+	// Only optional checks are failing, or some checks are degraded
+	return StatusDegraded, score
 }
@@ -0,0 +1,276 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	_mockToolsBroker "github.com/samuskitchen/go-health-checker/pkg/tools/mocks/broker"
+	_mockToolsDataStore "github.com/samuskitchen/go-health-checker/pkg/tools/mocks/datastore"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingChecker waits until its context is done
+var blockingChecker = CheckerFunc(func(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+})
+
+func TestRegistry_CheckerHealth_Concurrent(t *testing.T) {
+	registry := NewRegistry()
+
+	for _, name := range []string{"slow-1", "slow-2", "slow-3"} {
+		assert.NoError(t, registry.Register(Config{
+			Name: name,
+			Checker: CheckerFunc(func(context.Context) error {
+				time.Sleep(100 * time.Millisecond)
+				return nil
+			}),
+		}))
+	}
+
+	start := time.Now()
+	resp := registry.CheckerHealth(context.Background())
+
+	assert.Less(t, time.Since(start), 250*time.Millisecond)
+	assert.Equal(t, StatusAvailable, resp.OverallStatus)
+	assert.Equal(t, "slow-1", resp.Checks[0].Component)
+	assert.Equal(t, "slow-3", resp.Checks[2].Component)
+}
+
+func TestRegistry_CheckerHealth_Timeouts(t *testing.T) {
+	t.Run("per check timeout", func(t *testing.T) {
+		registry := NewRegistry()
+		assert.NoError(t, registry.Register(Config{
+			Name:    "fast",
+			Checker: CheckerFunc(func(context.Context) error { return nil }),
+		}))
+		assert.NoError(t, registry.Register(Config{
+			Name:    "hanging",
+			Timeout: 50 * time.Millisecond,
+			Checker: blockingChecker,
+		}))
+
+		resp := registry.CheckerHealth(context.Background())
+
+		assert.Equal(t, StatusDegraded, resp.OverallStatus)
+		assert.Equal(t, StatusOK, resp.Checks[0].Status)
+		assert.Equal(t, StatusTimeout, resp.Checks[1].Status)
+	})
+
+	t.Run("global deadline", func(t *testing.T) {
+		registry := NewRegistry()
+		for _, name := range []string{"hanging-1", "hanging-2"} {
+			assert.NoError(t, registry.Register(Config{Name: name, Timeout: time.Minute, Checker: blockingChecker}))
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		resp := registry.CheckerHealth(ctx)
+
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, StatusUnavailable, resp.OverallStatus)
+		assert.Len(t, resp.Checks, 2)
+		for _, check := range resp.Checks {
+			assert.Equal(t, StatusTimeout, check.Status)
+		}
+	})
+}
+
+func TestParseTimeouts(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		timeouts, err := ParseTimeouts(" postgresql-sql-connection=2s, rabbitmq-connection = 500ms ,")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]time.Duration{
+			"postgresql-sql-connection": 2 * time.Second,
+			"rabbitmq-connection":       500 * time.Millisecond,
+		}, timeouts)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		timeouts, err := ParseTimeouts("")
+		assert.NoError(t, err)
+		assert.Empty(t, timeouts)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseTimeouts("postgresql-sql-connection")
+		assert.Error(t, err)
+
+		_, err = ParseTimeouts("postgresql-sql-connection=fast")
+		assert.Error(t, err)
+	})
+}
+
+func TestCalculateOverallStatus(t *testing.T) {
+	ok := func(critical bool, weight float64) Health {
+		return Health{Status: StatusOK, Critical: critical, weight: weight}
+	}
+	failed := func(critical bool, weight float64) Health {
+		return Health{Status: StatusUnavailable, Critical: critical, weight: weight}
+	}
+
+	tests := []struct {
+		name          string
+		checks        []Health
+		expected      Status
+		expectedScore float64
+	}{
+		{name: "no checks", expected: StatusUnknown},
+		{name: "all ok", checks: []Health{ok(true, 1), ok(false, 1)}, expected: StatusAvailable, expectedScore: 1},
+		{
+			name:          "optional failing",
+			checks:        []Health{ok(true, 3), failed(false, 1)},
+			expected:      StatusDegraded,
+			expectedScore: 0.75,
+		},
+		{
+			name:          "critical failing",
+			checks:        []Health{failed(true, 1), ok(false, 3)},
+			expected:      StatusUnavailable,
+			expectedScore: 0.75,
+		},
+		{
+			name:          "critical degraded",
+			checks:        []Health{{Status: StatusDegraded, Critical: true, weight: 1}, ok(false, 1)},
+			expected:      StatusDegraded,
+			expectedScore: 0.75,
+		},
+		{
+			name:          "all optional failing",
+			checks:        []Health{failed(false, 1), failed(false, 1)},
+			expected:      StatusUnavailable,
+			expectedScore: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, score := calculateOverallStatus(tt.checks)
+			assert.Equal(t, tt.expected, status)
+			assert.InDelta(t, tt.expectedScore, score, 0.0001)
+		})
+	}
+}
+
+func TestRegistry_CheckerHealth_Critical(t *testing.T) {
+	registry := NewRegistry()
+	assert.NoError(t, registry.Register(Config{
+		Name:     "primary-db",
+		Critical: true,
+		Checker:  CheckerFunc(func(context.Context) error { return errors.New("connection refused") }),
+	}))
+	assert.NoError(t, registry.Register(Config{
+		Name:    "cache",
+		Checker: CheckerFunc(func(context.Context) error { return nil }),
+	}))
+
+	resp := registry.CheckerHealth(context.Background())
+
+	assert.Equal(t, StatusUnavailable, resp.OverallStatus)
+	assert.True(t, resp.Checks[0].Critical)
+	assert.False(t, resp.Checks[1].Critical)
+}
+
+func TestParseWeights(t *testing.T) {
+	weights, err := ParseWeights("postgresql-sql-connection=3, hazelcast-connection=0.5")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"postgresql-sql-connection": 3, "hazelcast-connection": 0.5}, weights)
+
+	_, err = ParseWeights("hazelcast-connection=-1")
+	assert.Error(t, err)
+}
+
+func TestParseCounts(t *testing.T) {
+	counts, err := ParseCounts("beers=100, orders=0")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"beers": 100, "orders": 0}, counts)
+
+	_, err = ParseCounts("beers=-1")
+	assert.Error(t, err)
+}
+
+func TestParseNames(t *testing.T) {
+	assert.Equal(t, map[string]bool{"postgresql-sql-connection": true, "rabbitmq-connection": true},
+		ParseNames("postgresql-sql-connection, rabbitmq-connection,"))
+	assert.Empty(t, ParseNames(""))
+}
+
+func TestRegistry_CheckerHealth_Details(t *testing.T) {
+	var fail atomic.Bool
+	fail.Store(true)
+
+	registry := NewRegistry()
+	assert.NoError(t, registry.Register(Config{
+		Name: "flaky",
+		Checker: CheckerFunc(func(context.Context) error {
+			time.Sleep(5 * time.Millisecond)
+			if fail.Load() {
+				return errors.New("connection refused")
+			}
+			return nil
+		}),
+	}))
+
+	registry.CheckerHealth(context.Background())
+	check := registry.CheckerHealth(context.Background()).Checks[0]
+
+	assert.Equal(t, StatusUnavailable, check.Status)
+	assert.Equal(t, "connection refused", check.Error)
+	assert.Equal(t, 2, check.ConsecutiveFailures)
+	assert.NotNil(t, check.LastFailure)
+	assert.Nil(t, check.LastSuccess)
+	assert.GreaterOrEqual(t, check.DurationMs, 5.0)
+
+	fail.Store(false)
+	check = registry.CheckerHealth(context.Background()).Checks[0]
+
+	assert.Equal(t, StatusOK, check.Status)
+	assert.Empty(t, check.Error)
+	assert.Zero(t, check.ConsecutiveFailures)
+	assert.NotNil(t, check.LastSuccess)
+	assert.NotNil(t, check.LastFailure, "the last failure is kept after recovering")
+}
+
+func TestRegistry_CheckerHealth_TimeoutError(t *testing.T) {
+	registry := NewRegistry()
+	assert.NoError(t, registry.Register(Config{Name: "hanging", Timeout: 10 * time.Millisecond, Checker: blockingChecker}))
+
+	check := registry.CheckerHealth(context.Background()).Checks[0]
+
+	assert.Equal(t, StatusTimeout, check.Status)
+	assert.Contains(t, check.Error, "did not finish in time")
+}
+
+func TestClients_CheckerHealth_Versions(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectPing()
+	mock.ExpectQuery("SHOW server_version").
+		WillReturnRows(sqlmock.NewRows([]string{"server_version"}).AddRow("16.2"))
+
+	rabbitClient := _mockToolsBroker.NewMockClient(t)
+	rabbitClient.On("Ping").Return(nil)
+	rabbitClient.On("ServerVersion").Return("RabbitMQ 3.13.7", nil)
+
+	hazelcastClient := _mockToolsDataStore.NewMockIClient(t)
+	hazelcastClient.On("Ping").Return(nil)
+	hazelcastClient.On("ServerVersion").Return("", errors.New("hazelcast cluster has no known members"))
+
+	clients := Clients{RabbitClient: rabbitClient, HazelcastClient: hazelcastClient, PgClient: db}
+	resp := clients.Registry().CheckerHealth(context.Background())
+
+	assert.Equal(t, StatusAvailable, resp.OverallStatus)
+	assert.Equal(t, "RabbitMQ 3.13.7", resp.Checks[0].Version)
+	assert.Empty(t, resp.Checks[1].Version, "a failed lookup does not fail the check")
+	assert.Equal(t, "16.2", resp.Checks[2].Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,87 @@
+package healthcheck
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultHistorySize is the number of runs kept per component by a History when no size is given
+const DefaultHistorySize = 30
+
+// Sample is the result of a component in a single run
+type Sample struct {
+	Status     Status
+	DurationMs float64
+	At         time.Time
+}
+
+// ComponentHistory is what a History remembers about a component
+type ComponentHistory struct {
+	// Samples are the latest results of the component, oldest first
+	Samples []Sample
+	// LastChange is when the component got its current status, its first run when it never changed
+	LastChange time.Time
+	// LastError is the latest reason the component failed or was degraded, even if it recovered since
+	LastError   string
+	LastErrorAt *time.Time
+}
+
+// History is an Observer that remembers the latest results of every component, for the status dashboard
+type History struct {
+	mu         sync.RWMutex
+	size       int
+	components map[string]*ComponentHistory
+}
+
+// NewHistory creates a History keeping the latest size runs of every component, DefaultHistorySize when not positive
+func NewHistory(size int) *History {
+	if size <= 0 {
+		size = DefaultHistorySize
+	}
+
+	return &History{size: size, components: map[string]*ComponentHistory{}}
+}
+
+// ObserveHealth appends the result of every check of resp to the history of its component
+func (h *History) ObserveHealth(resp Response) {
+	at := time.Now()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, check := range resp.Checks {
+		component, ok := h.components[check.Component]
+		if !ok {
+			component = &ComponentHistory{LastChange: at}
+			h.components[check.Component] = component
+		} else if last := component.Samples[len(component.Samples)-1]; last.Status != check.Status {
+			component.LastChange = at
+		}
+
+		if check.Error != "" {
+			component.LastError = check.Error
+			component.LastErrorAt = &at
+		}
+
+		component.Samples = append(component.Samples, Sample{Status: check.Status, DurationMs: check.DurationMs, At: at})
+		if len(component.Samples) > h.size {
+			component.Samples = append(component.Samples[:0:0], component.Samples[len(component.Samples)-h.size:]...)
+		}
+	}
+}
+
+// Component returns a copy of the history of the named component, reporting false when it never ran
+func (h *History) Component(name string) (ComponentHistory, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	component, ok := h.components[name]
+	if !ok {
+		return ComponentHistory{}, false
+	}
+
+	history := *component
+	history.Samples = append([]Sample(nil), component.Samples...)
+
+	return history, true
+}
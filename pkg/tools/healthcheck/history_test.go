@@ -0,0 +1,52 @@
+package healthcheck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistory(t *testing.T) {
+	history := NewHistory(3)
+
+	_, ok := history.Component("Redis")
+	assert.False(t, ok, "no history before the first run")
+
+	history.ObserveHealth(Response{Checks: []Health{{Component: "Redis", Status: StatusOK, DurationMs: 1}}})
+	first, ok := history.Component("Redis")
+	assert.True(t, ok)
+	assert.Len(t, first.Samples, 1)
+	assert.Empty(t, first.LastError)
+
+	history.ObserveHealth(Response{Checks: []Health{{Component: "Redis", Status: StatusUnavailable, Error: "timeout"}}})
+	failed, _ := history.Component("Redis")
+	assert.False(t, failed.LastChange.Before(first.LastChange))
+	assert.Equal(t, "timeout", failed.LastError)
+	assert.NotNil(t, failed.LastErrorAt)
+
+	history.ObserveHealth(Response{Checks: []Health{{Component: "Redis", Status: StatusOK}}})
+	history.ObserveHealth(Response{Checks: []Health{{Component: "Redis", Status: StatusOK}}})
+	recovered, _ := history.Component("Redis")
+	assert.Equal(t, []Status{StatusUnavailable, StatusOK, StatusOK}, sampleStatuses(recovered.Samples),
+		"only the latest runs are kept")
+	assert.Equal(t, "timeout", recovered.LastError, "the last error is kept after recovering")
+
+	unchanged := recovered.LastChange
+	history.ObserveHealth(Response{Checks: []Health{{Component: "Redis", Status: StatusOK}}})
+	latest, _ := history.Component("Redis")
+	assert.Equal(t, unchanged, latest.LastChange, "the last change only moves with the status")
+
+	recovered.Samples[0].Status = StatusTimeout
+	latest, _ = history.Component("Redis")
+	assert.Equal(t, StatusOK, latest.Samples[0].Status, "callers get a copy")
+}
+
+// sampleStatuses returns the status of every sample
+func sampleStatuses(samples []Sample) []Status {
+	statuses := make([]Status, len(samples))
+	for i, sample := range samples {
+		statuses[i] = sample.Status
+	}
+
+	return statuses
+}
@@ -0,0 +1,151 @@
+package healthcheck
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// maxHTTPBody bounds the part of the response body read by the HTTP checker
+const maxHTTPBody = 1 << 20
+
+// HTTPChecker checks an upstream HTTP dependency
+type HTTPChecker struct {
+	// URL is the endpoint to call, e.g. "https://catalog.internal/health"
+	URL string
+	// Method is the HTTP method of the request, http.MethodGet when empty
+	Method string
+	// Headers are added to the request, e.g. an Authorization header
+	Headers map[string]string
+	// ExpectedStatus are the accepted status codes, any 2xx status when empty
+	ExpectedStatus []int
+	// BodyContains fails the check when the response body does not contain it
+	BodyContains string
+	// JSONPath is a dot separated path into the JSON response body, e.g. "status" or "checks.0.status".
+	// The check fails when the value at JSONPath is not JSONValue.
+	JSONPath  string
+	JSONValue string
+	// TLSConfig configures the TLS connection, e.g. a custom CA or a client certificate
+	TLSConfig *tls.Config
+	// Client performs the request, a client using TLSConfig when nil.
+	// The timeout of the request is the timeout of the check.
+	Client *http.Client
+
+	once      sync.Once
+	tlsClient *http.Client
+}
+
+// Check calls the endpoint and verifies its response
+func (hc *HTTPChecker) Check(ctx context.Context) error {
+	method := hc.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, hc.URL, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+
+	for key, value := range hc.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := hc.client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if !hc.statusExpected(resp.StatusCode) {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	if hc.BodyContains == "" && hc.JSONPath == "" {
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPBody))
+	if err != nil {
+		return fmt.Errorf("failed to read the response body: %w", err)
+	}
+
+	if hc.BodyContains != "" && !strings.Contains(string(body), hc.BodyContains) {
+		return fmt.Errorf("response body does not contain %q", hc.BodyContains)
+	}
+
+	if hc.JSONPath != "" {
+		return hc.assertJSON(body)
+	}
+
+	return nil
+}
+
+// client returns the configured client or one using the TLS configuration
+func (hc *HTTPChecker) client() *http.Client {
+	if hc.Client != nil {
+		return hc.Client
+	}
+
+	if hc.TLSConfig == nil {
+		return http.DefaultClient
+	}
+
+	// Built once so that the connections are reused across checks
+	hc.once.Do(func() {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = hc.TLSConfig
+		hc.tlsClient = &http.Client{Transport: transport}
+	})
+
+	return hc.tlsClient
+}
+
+// statusExpected reports whether the status code is one of the expected ones
+func (hc *HTTPChecker) statusExpected(code int) bool {
+	if len(hc.ExpectedStatus) == 0 {
+		return code >= http.StatusOK && code < http.StatusMultipleChoices
+	}
+
+	return slices.Contains(hc.ExpectedStatus, code)
+}
+
+// assertJSON verifies that the value at JSONPath in the body is JSONValue
+func (hc *HTTPChecker) assertJSON(body []byte) error {
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Errorf("response body is not JSON: %w", err)
+	}
+
+	for _, key := range strings.Split(hc.JSONPath, ".") {
+		switch node := value.(type) {
+		case map[string]any:
+			child, ok := node[key]
+			if !ok {
+				return fmt.Errorf("JSON path %q not found", hc.JSONPath)
+			}
+			value = child
+		case []any:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(node) {
+				return fmt.Errorf("JSON path %q not found", hc.JSONPath)
+			}
+			value = node[index]
+		default:
+			return fmt.Errorf("JSON path %q not found", hc.JSONPath)
+		}
+	}
+
+	if got := fmt.Sprint(value); got != hc.JSONValue {
+		return fmt.Errorf("JSON path %q is %q, want %q", hc.JSONPath, got, hc.JSONValue)
+	}
+
+	return nil
+}
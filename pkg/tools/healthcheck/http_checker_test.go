@@ -0,0 +1,131 @@
+package healthcheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPChecker_Check(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":"UP","checks":[{"name":"db","status":"DOWN"}]}`))
+		case "/accepted":
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	headers := map[string]string{"Authorization": "Bearer token"}
+
+	tests := []struct {
+		name    string
+		checker *HTTPChecker
+		err     string
+	}{
+		{name: "2xx by default", checker: &HTTPChecker{URL: server.URL + "/accepted"}},
+		{
+			name:    "unexpected status",
+			checker: &HTTPChecker{URL: server.URL + "/broken"},
+			err:     "unexpected status code 500",
+		},
+		{
+			name:    "expected status",
+			checker: &HTTPChecker{URL: server.URL + "/broken", ExpectedStatus: []int{http.StatusInternalServerError}},
+		},
+		{
+			name:    "headers",
+			checker: &HTTPChecker{URL: server.URL + "/health"},
+			err:     "unexpected status code 401",
+		},
+		{
+			name:    "body contains",
+			checker: &HTTPChecker{URL: server.URL + "/health", Headers: headers, BodyContains: `"status":"UP"`},
+		},
+		{
+			name:    "body does not contain",
+			checker: &HTTPChecker{URL: server.URL + "/health", Headers: headers, BodyContains: "healthy"},
+			err:     `response body does not contain "healthy"`,
+		},
+		{
+			name:    "JSON path",
+			checker: &HTTPChecker{URL: server.URL + "/health", Headers: headers, JSONPath: "status", JSONValue: "UP"},
+		},
+		{
+			name: "JSON path mismatch",
+			checker: &HTTPChecker{
+				URL:       server.URL + "/health",
+				Headers:   headers,
+				JSONPath:  "checks.0.status",
+				JSONValue: "UP",
+			},
+			err: `JSON path "checks.0.status" is "DOWN", want "UP"`,
+		},
+		{
+			name:    "JSON path not found",
+			checker: &HTTPChecker{URL: server.URL + "/health", Headers: headers, JSONPath: "checks.3.status"},
+			err:     `JSON path "checks.3.status" not found`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.checker.Check(ctx)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestHTTPChecker_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// The test server certificate is not trusted by the system
+	assert.Error(t, (&HTTPChecker{URL: server.URL}).Check(context.Background()))
+
+	tlsConfig := server.Client().Transport.(*http.Transport).TLSClientConfig
+	assert.NoError(t, (&HTTPChecker{URL: server.URL, TLSConfig: tlsConfig}).Check(context.Background()))
+}
+
+func TestClients_Registry_HTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	clients := Clients{
+		HTTP:     map[string]*HTTPChecker{"catalog-api": {URL: server.URL}},
+		Critical: map[string]bool{"catalog-api": true},
+	}
+	resp := clients.Registry().CheckerHealth(context.Background())
+
+	assert.Equal(t, StatusUnavailable, resp.OverallStatus)
+	assert.Equal(t, "catalog-api", resp.Checks[0].Component)
+	assert.Equal(t, "unexpected status code 503", resp.Checks[0].Error)
+}
+
+func TestParseURLs(t *testing.T) {
+	urls, err := ParseURLs("catalog-api=https://catalog.internal/health?full=true")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"catalog-api": "https://catalog.internal/health?full=true"}, urls)
+
+	_, err = ParseURLs("catalog-api=catalog")
+	assert.Error(t, err)
+}
@@ -0,0 +1,83 @@
+package healthcheck
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Observer is notified with the response of every run of a Scheduler
+type Observer interface {
+	ObserveHealth(resp Response)
+}
+
+// Metrics publishes the results of the health checks as Prometheus metrics
+type Metrics struct {
+	mu       sync.Mutex
+	up       *prometheus.GaugeVec
+	duration *prometheus.HistogramVec
+	score    prometheus.Gauge
+	// critical holds the critical label of every component of the previous run, to delete the series of the removed ones
+	critical map[string]string
+}
+
+// NewMetrics registers the health check metrics in registerer
+func NewMetrics(registerer prometheus.Registerer) *Metrics {
+	factory := promauto.With(registerer)
+
+	return &Metrics{
+		up: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "health_check_up",
+			Help: "Whether the last health check of the component passed (1) or not (0).",
+		}, []string{"component", "critical"}),
+		duration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "health_check_duration_seconds",
+			Help:    "Duration of the health checks in seconds.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"component"}),
+		score: factory.NewGauge(prometheus.GaugeOpts{
+			Name: "health_score",
+			Help: "Weighted share of passing health checks, from 0 to 1.",
+		}),
+		critical: map[string]string{},
+	}
+}
+
+// ObserveHealth records the status of every check of resp and the duration of the checks that ran.
+// The series of the checks that are no longer in resp, e.g. after a reload, are deleted.
+func (m *Metrics) ObserveHealth(resp Response) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.score.Set(resp.Score)
+
+	critical := make(map[string]string, len(resp.Checks))
+	for _, check := range resp.Checks {
+		up := 0.0
+		if check.Status.passed() {
+			up = 1
+		}
+
+		critical[check.Component] = strconv.FormatBool(check.Critical)
+		m.up.WithLabelValues(check.Component, critical[check.Component]).Set(up)
+
+		// A cached result was already observed when its check ran
+		if !check.cached {
+			m.duration.WithLabelValues(check.Component).Observe(check.DurationMs / 1000)
+		}
+	}
+
+	for component, previous := range m.critical {
+		current, ok := critical[component]
+		if !ok {
+			m.duration.DeleteLabelValues(component)
+		}
+		if current != previous {
+			m.up.DeleteLabelValues(component, previous)
+		}
+	}
+
+	m.critical = critical
+}
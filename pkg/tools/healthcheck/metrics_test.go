@@ -0,0 +1,96 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics_ObserveHealth(t *testing.T) {
+	registry := NewRegistry()
+	assert.NoError(t, registry.Register(Config{
+		Name:     "db",
+		Critical: true,
+		Weight:   3,
+		Checker:  CheckerFunc(func(context.Context) error { return nil }),
+	}))
+	assert.NoError(t, registry.Register(Config{
+		Name:    "cache",
+		Checker: CheckerFunc(func(context.Context) error { return errors.New("connection refused") }),
+	}))
+
+	promRegistry := prometheus.NewRegistry()
+	scheduler := NewScheduler(registry, 0, time.Second)
+	scheduler.AddObserver(NewMetrics(promRegistry))
+
+	scheduler.Run(context.Background())
+
+	expected := `
+# HELP health_check_up Whether the last health check of the component passed (1) or not (0).
+# TYPE health_check_up gauge
+health_check_up{component="cache",critical="false"} 0
+health_check_up{component="db",critical="true"} 1
+# HELP health_score Weighted share of passing health checks, from 0 to 1.
+# TYPE health_score gauge
+health_score 0.75
+`
+	assert.NoError(t, testutil.GatherAndCompare(promRegistry, strings.NewReader(expected),
+		"health_check_up", "health_score"))
+	assert.Equal(t, 2, testutil.CollectAndCount(promRegistry, "health_check_duration_seconds"))
+}
+
+// durationCounts returns the number of observed durations of every component
+func durationCounts(t *testing.T, registry *prometheus.Registry) map[string]uint64 {
+	families, err := registry.Gather()
+	assert.NoError(t, err)
+
+	counts := map[string]uint64{}
+	for _, family := range families {
+		if family.GetName() != "health_check_duration_seconds" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			counts[metric.GetLabel()[0].GetValue()] = metric.GetHistogram().GetSampleCount()
+		}
+	}
+
+	return counts
+}
+
+func TestMetrics_ObserveHealth_CachedAndRemoved(t *testing.T) {
+	registry := NewRegistry()
+	assert.NoError(t, registry.Register(Config{
+		Name:     "db",
+		Interval: time.Hour,
+		Checker:  CheckerFunc(func(context.Context) error { return nil }),
+	}))
+	assert.NoError(t, registry.Register(Config{
+		Name:    "cache",
+		Checker: CheckerFunc(func(context.Context) error { return nil }),
+	}))
+
+	promRegistry := prometheus.NewRegistry()
+	metrics := NewMetrics(promRegistry)
+
+	metrics.ObserveHealth(registry.CheckerHealth(context.Background()))
+	metrics.ObserveHealth(registry.CheckerHealth(context.Background()))
+	assert.Equal(t, map[string]uint64{"db": 1, "cache": 2}, durationCounts(t, promRegistry),
+		"a cached result is not observed again")
+
+	reloaded := Response{Score: 1, Checks: []Health{{Component: "db", Status: StatusOK, Critical: true}}}
+	metrics.ObserveHealth(reloaded)
+
+	expected := `
+# HELP health_check_up Whether the last health check of the component passed (1) or not (0).
+# TYPE health_check_up gauge
+health_check_up{component="db",critical="true"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(promRegistry, strings.NewReader(expected), "health_check_up"))
+	assert.Equal(t, map[string]uint64{"db": 2}, durationCounts(t, promRegistry))
+}
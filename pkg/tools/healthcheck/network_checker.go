@@ -0,0 +1,99 @@
+package healthcheck
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// TCPChecker checks that a TCP endpoint accepts connections, e.g. an SMTP relay or a legacy socket
+type TCPChecker struct {
+	// Address is the host:port to dial
+	Address string
+	// Banner fails the check when the first line sent by the server does not contain it, e.g. "220" for SMTP
+	Banner string
+	// Dialer opens the connection, a zero net.Dialer when nil.
+	// The timeout of the connection is the timeout of the check.
+	Dialer *net.Dialer
+}
+
+// Check dials the endpoint and, when a banner is expected, reads the first line it sends
+func (tc *TCPChecker) Check(ctx context.Context) error {
+	dialer := tc.Dialer
+	if dialer == nil {
+		dialer = &net.Dialer{}
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", tc.Address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if tc.Banner == "" {
+		return nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetReadDeadline(deadline); err != nil {
+			return err
+		}
+	}
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil && line == "" {
+		return fmt.Errorf("failed to read the banner: %w", err)
+	}
+
+	if !strings.Contains(line, tc.Banner) {
+		return fmt.Errorf("banner %q does not contain %q", strings.TrimSpace(line), tc.Banner)
+	}
+
+	return nil
+}
+
+// DNSChecker checks that a hostname resolves to enough addresses
+type DNSChecker struct {
+	// Host is the hostname to resolve
+	Host string
+	// MinRecords fails the check when fewer addresses are found, 1 when zero
+	MinRecords int
+	// Server is the host:port of the DNS server to ask, the system resolver is used when empty
+	Server string
+}
+
+// Check resolves the hostname and counts its addresses
+func (dc *DNSChecker) Check(ctx context.Context) error {
+	addresses, err := dc.resolver().LookupHost(ctx, dc.Host)
+	if err != nil {
+		return err
+	}
+
+	minRecords := dc.MinRecords
+	if minRecords <= 0 {
+		minRecords = 1
+	}
+
+	if len(addresses) < minRecords {
+		return fmt.Errorf("%s resolved to %d records, at least %d are required", dc.Host, len(addresses), minRecords)
+	}
+
+	return nil
+}
+
+// resolver returns a resolver asking Server, or the system resolver
+func (dc *DNSChecker) resolver() *net.Resolver {
+	if dc.Server == "" {
+		return net.DefaultResolver
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, dc.Server)
+		},
+	}
+}
@@ -0,0 +1,71 @@
+package healthcheck
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTCPChecker_Check(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_, _ = conn.Write([]byte("220 smtp ready\r\n"))
+			_ = conn.Close()
+		}
+	}()
+
+	address := listener.Addr().String()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	assert.NoError(t, (&TCPChecker{Address: address}).Check(ctx))
+	assert.NoError(t, (&TCPChecker{Address: address, Banner: "220"}).Check(ctx))
+	assert.EqualError(t, (&TCPChecker{Address: address, Banner: "SSH-2.0"}).Check(ctx),
+		`banner "220 smtp ready" does not contain "SSH-2.0"`)
+
+	// Nothing listens once the listener is closed
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	_ = closed.Close()
+	assert.Error(t, (&TCPChecker{Address: closed.Addr().String()}).Check(ctx))
+}
+
+func TestDNSChecker_Check(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	assert.NoError(t, (&DNSChecker{Host: "127.0.0.1"}).Check(ctx))
+	assert.EqualError(t, (&DNSChecker{Host: "127.0.0.1", MinRecords: 2}).Check(ctx),
+		"127.0.0.1 resolved to 1 records, at least 2 are required")
+}
+
+func TestClients_Registry_Network(t *testing.T) {
+	clients := Clients{
+		TCP: map[string]*TCPChecker{"smtp-relay": {Address: "127.0.0.1:0"}},
+		DNS: map[string]*DNSChecker{"catalog-dns": {Host: "127.0.0.1"}},
+	}
+	resp := clients.Registry().CheckerHealth(context.Background())
+
+	assert.Len(t, resp.Checks, 2)
+	assert.Equal(t, StatusDegraded, resp.OverallStatus)
+}
+
+func TestParseAddresses(t *testing.T) {
+	addresses, err := ParseAddresses("smtp-relay=smtp.internal:25")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"smtp-relay": "smtp.internal:25"}, addresses)
+
+	_, err = ParseAddresses("smtp-relay=smtp.internal")
+	assert.Error(t, err)
+}
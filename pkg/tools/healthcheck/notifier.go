@@ -0,0 +1,347 @@
+package healthcheck
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Defaults of the webhook notifier
+const (
+	// OverallComponent is the component of the transitions of the overall status
+	OverallComponent = "overall"
+	// SignatureHeader holds the hex HMAC-SHA256 of the payload, prefixed by "sha256=", when the webhook has a secret
+	SignatureHeader = "X-Health-Signature"
+	// DefaultWebhookRetries is the number of retries of a failed delivery when none is configured
+	DefaultWebhookRetries = 3
+	// DefaultWebhookBackoff is the wait before the first retry, doubled on every retry, when none is configured
+	DefaultWebhookBackoff = 500 * time.Millisecond
+	// webhookQueueSize bounds the transitions waiting to be delivered to a webhook
+	webhookQueueSize = 100
+)
+
+// Severity ranks a transition by the worst of its previous and current status,
+// so that recovering from a failure is as severe as the failure itself
+type Severity int
+
+// Severities of the transitions
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityCritical
+)
+
+// ParseSeverity parses "info", "warning" or "critical"
+func ParseSeverity(value string) (Severity, error) {
+	for severity := SeverityInfo; severity <= SeverityCritical; severity++ {
+		if severity.String() == value {
+			return severity, nil
+		}
+	}
+
+	return SeverityInfo, fmt.Errorf("unknown severity %q", value)
+}
+
+// String returns the name of the severity
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return "info"
+	}
+}
+
+// MarshalJSON encodes the severity by its name
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// severityOf returns the severity of a status
+func severityOf(status Status) Severity {
+	switch status {
+	case StatusOK, StatusAvailable, StatusUnknown:
+		return SeverityInfo
+	case StatusDegraded:
+		return SeverityWarning
+	default:
+		return SeverityCritical
+	}
+}
+
+// Transition is the payload posted to the webhooks and published to the broker
+// when the status of a component or the overall status changes
+type Transition struct {
+	// Component is the component whose status changed, OverallComponent for the overall status
+	Component      string   `json:"component"`
+	PreviousStatus Status   `json:"previousStatus"`
+	Status         Status   `json:"status"`
+	Severity       Severity `json:"severity"`
+	Critical       bool     `json:"critical"`
+	Error          string   `json:"error,omitempty"`
+	Timestamp      string   `json:"timestamp"`
+	// Instance identifies the instance that ran the checks, omitted when not configured
+	Instance string `json:"instance,omitempty"`
+}
+
+// transitionTracker detects the transitions of the overall status and of every component between two runs.
+// It is not safe for concurrent use.
+type transitionTracker struct {
+	instance string
+	statuses map[string]Status
+}
+
+// transitions compares resp with the statuses of the previous run and records the new ones.
+// The first run only records the statuses.
+func (t *transitionTracker) transitions(resp Response) []Transition {
+	first := t.statuses == nil
+	current := make(map[string]Status, len(resp.Checks)+1)
+	var transitions []Transition
+
+	add := func(component string, status Status, critical bool, errMsg string) {
+		current[component] = status
+
+		previous, ok := t.statuses[component]
+		if first || (ok && previous == status) {
+			return
+		}
+
+		if !ok {
+			previous = StatusUnknown
+		}
+
+		transitions = append(transitions, Transition{
+			Component:      component,
+			PreviousStatus: previous,
+			Status:         status,
+			Severity:       max(severityOf(previous), severityOf(status)),
+			Critical:       critical,
+			Error:          errMsg,
+			Timestamp:      resp.Timestamp,
+			Instance:       t.instance,
+		})
+	}
+
+	add(OverallComponent, resp.OverallStatus, true, "")
+	for _, check := range resp.Checks {
+		add(check.Component, check.Status, check.Critical, check.Error)
+	}
+
+	t.statuses = current
+	return transitions
+}
+
+// Webhook is an endpoint notified of the status transitions
+type Webhook struct {
+	// URL receives a POST with the JSON Transition
+	URL string
+	// Secret signs the payload in the SignatureHeader, no signature is sent when empty
+	Secret string
+	// Components only notifies the transitions of these components, all of them when empty.
+	// OverallComponent selects the transitions of the overall status.
+	Components map[string]bool
+	// MinSeverity only notifies the transitions at least this severe
+	MinSeverity Severity
+}
+
+// accepts reports whether the webhook is notified of transition
+func (w *Webhook) accepts(transition Transition) bool {
+	if len(w.Components) > 0 && !w.Components[transition.Component] {
+		return false
+	}
+
+	return transition.Severity >= w.MinSeverity
+}
+
+// NotifierConfig configures the delivery of the notifications
+type NotifierConfig struct {
+	// Retries is the number of retries of a failed delivery, DefaultWebhookRetries when zero
+	Retries int
+	// Backoff is the wait before the first retry, doubled on every retry, DefaultWebhookBackoff when zero
+	Backoff time.Duration
+	// Client posts the notifications, a client with a 5s timeout when nil
+	Client *http.Client
+	// Instance identifies this instance in the transitions
+	Instance string
+}
+
+// Notifier detects the transitions of the overall status and of every component between two runs
+// of a Scheduler and posts them to the webhooks. The first run only records the statuses.
+// The live runs of Scheduler.Check are never observed, so a request going away cannot fire a transition.
+// Every webhook is notified in order by its own goroutine, so a slow endpoint never delays the checks.
+type Notifier struct {
+	config   NotifierConfig
+	webhooks []Webhook
+	queues   []chan []byte
+
+	mu      sync.Mutex
+	tracker transitionTracker
+	closed  bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewNotifier starts delivering the transitions to webhooks until Close is called
+func NewNotifier(webhooks []Webhook, config NotifierConfig) *Notifier {
+	if config.Retries <= 0 {
+		config.Retries = DefaultWebhookRetries
+	}
+
+	if config.Backoff <= 0 {
+		config.Backoff = DefaultWebhookBackoff
+	}
+
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: DefaultTimeout}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	n := &Notifier{
+		config:   config,
+		webhooks: webhooks,
+		queues:   make([]chan []byte, len(webhooks)),
+		tracker:  transitionTracker{instance: config.Instance},
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+
+	for i := range webhooks {
+		n.queues[i] = make(chan []byte, webhookQueueSize)
+		n.wg.Add(1)
+		go n.deliverAll(&n.webhooks[i], n.queues[i])
+	}
+
+	return n
+}
+
+// ObserveHealth queues the transitions since the previous run for the webhooks that accept them
+func (n *Notifier) ObserveHealth(resp Response) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.closed {
+		return
+	}
+
+	for _, transition := range n.tracker.transitions(resp) {
+		payload, err := json.Marshal(transition)
+		if err != nil {
+			continue
+		}
+
+		for i := range n.webhooks {
+			if !n.webhooks[i].accepts(transition) {
+				continue
+			}
+
+			select {
+			case n.queues[i] <- payload:
+			default:
+				log.Warn().Msgf("Warning: webhook %s is backing up, dropping the %s transition",
+					n.webhooks[i].URL, transition.Component)
+			}
+		}
+	}
+}
+
+// Close stops accepting transitions and waits for the deliveries in progress to end.
+// Failed deliveries are no longer retried, so the transitions still queued may be lost.
+func (n *Notifier) Close() {
+	n.mu.Lock()
+	if n.closed {
+		n.mu.Unlock()
+		return
+	}
+
+	n.closed = true
+	for _, queue := range n.queues {
+		close(queue)
+	}
+	n.mu.Unlock()
+
+	n.cancel()
+	n.wg.Wait()
+}
+
+// deliverAll posts every payload of queue to the webhook until the queue is closed
+func (n *Notifier) deliverAll(webhook *Webhook, queue <-chan []byte) {
+	defer n.wg.Done()
+
+	for payload := range queue {
+		if err := n.deliver(webhook, payload); err != nil {
+			log.Warn().Msgf("Warning: failed to notify webhook %s: %v", webhook.URL, err)
+		}
+	}
+}
+
+// deliver posts payload to the webhook, retrying with an exponential backoff
+// on network errors, 429 and 5xx responses
+func (n *Notifier) deliver(webhook *Webhook, payload []byte) error {
+	backoff := n.config.Backoff
+
+	var err error
+	for attempt := 0; attempt <= n.config.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-n.ctx.Done():
+				return err
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		var retry bool
+		if retry, err = n.post(webhook, payload); err == nil || !retry {
+			return err
+		}
+	}
+
+	return err
+}
+
+// post sends a single notification, reporting whether a failure is worth retrying
+func (n *Notifier) post(webhook *Webhook, payload []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(n.ctx, http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return false, fmt.Errorf("invalid request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if webhook.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(webhook.Secret, payload))
+	}
+
+	resp, err := n.config.Client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return false, nil
+	}
+
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+	return retry, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+}
+
+// Sign returns the value of the SignatureHeader for payload, for receivers to verify it
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
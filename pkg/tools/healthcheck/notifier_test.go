@@ -0,0 +1,182 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// receiver is a local webhook endpoint recording the transitions it receives
+type receiver struct {
+	mu          sync.Mutex
+	transitions []map[string]any
+	unsigned    int
+	failures    int
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.failures > 0 {
+		r.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	body, _ := io.ReadAll(req.Body)
+	var transition map[string]any
+	_ = json.Unmarshal(body, &transition)
+
+	r.transitions = append(r.transitions, transition)
+	if req.Header.Get(SignatureHeader) != Sign("s3cr3t", body) {
+		r.unsigned++
+	}
+}
+
+func (r *receiver) received() []map[string]any {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]map[string]any(nil), r.transitions...)
+}
+
+func TestNotifier(t *testing.T) {
+	all := &receiver{failures: 2}
+	allServer := httptest.NewServer(all)
+	defer allServer.Close()
+
+	redisCritical := &receiver{}
+	redisServer := httptest.NewServer(redisCritical)
+	defer redisServer.Close()
+
+	notifier := NewNotifier([]Webhook{
+		{URL: allServer.URL, Secret: "s3cr3t"},
+		{URL: redisServer.URL, Secret: "s3cr3t", Components: map[string]bool{"Redis": true},
+			MinSeverity: SeverityCritical},
+	}, NotifierConfig{Backoff: time.Millisecond})
+
+	healthy := Response{
+		OverallStatus: StatusAvailable,
+		Checks: []Health{
+			{Component: "Redis", Status: StatusOK},
+			{Component: "RabbitMQ", Status: StatusOK},
+		},
+	}
+
+	// The first run only records the statuses
+	notifier.ObserveHealth(healthy)
+
+	notifier.ObserveHealth(Response{
+		OverallStatus: StatusDegraded,
+		Checks: []Health{
+			{Component: "Redis", Status: StatusOK},
+			{Component: "RabbitMQ", Status: StatusDegraded, Error: "degraded: queue backing up"},
+		},
+	})
+	notifier.ObserveHealth(Response{
+		OverallStatus: StatusUnavailable,
+		Checks: []Health{
+			{Component: "Redis", Status: StatusUnavailable, Critical: true, Error: "connection refused"},
+			{Component: "RabbitMQ", Status: StatusDegraded, Error: "degraded: queue backing up"},
+		},
+	})
+	notifier.ObserveHealth(healthy)
+	notifier.ObserveHealth(healthy)
+
+	assert.Eventually(t, func() bool { return len(all.received()) == 7 && len(redisCritical.received()) == 2 },
+		time.Second, 5*time.Millisecond)
+	notifier.Close()
+
+	components := make([]string, 0, len(all.received()))
+	for _, transition := range all.received() {
+		components = append(components, transition["component"].(string))
+	}
+	assert.Equal(t, []string{"overall", "RabbitMQ", "overall", "Redis", "overall", "Redis", "RabbitMQ"}, components)
+	assert.Zero(t, all.unsigned, "every payload is signed with the secret")
+
+	down := redisCritical.received()[0]
+	assert.Equal(t, "Redis", down["component"])
+	assert.Equal(t, "OK", down["previousStatus"])
+	assert.Equal(t, "Unavailable", down["status"])
+	assert.Equal(t, "critical", down["severity"])
+	assert.Equal(t, true, down["critical"])
+	assert.Equal(t, "connection refused", down["error"])
+
+	recovered := redisCritical.received()[1]
+	assert.Equal(t, "OK", recovered["status"])
+	assert.Equal(t, "critical", recovered["severity"])
+}
+
+func TestNotifier_SchedulerRuns(t *testing.T) {
+	webhook := &receiver{}
+	server := httptest.NewServer(webhook)
+	defer server.Close()
+
+	var failing atomic.Bool
+	registry := NewRegistry()
+	assert.NoError(t, registry.Register(Config{
+		Name: "database",
+		Checker: CheckerFunc(func(context.Context) error {
+			if failing.Load() {
+				return errors.New("connection refused")
+			}
+			return nil
+		}),
+	}))
+
+	notifier := NewNotifier([]Webhook{{URL: server.URL}}, NotifierConfig{})
+	defer notifier.Close()
+
+	scheduler := NewScheduler(registry, time.Hour, time.Second)
+	scheduler.AddObserver(notifier)
+	scheduler.Start()
+	defer scheduler.Stop()
+	assert.Eventually(t, func() bool { _, ok := scheduler.Snapshot(); return ok }, time.Second, 5*time.Millisecond)
+
+	failing.Store(true)
+	assert.Equal(t, StatusUnavailable, scheduler.Check(context.Background()).OverallStatus)
+	time.Sleep(20 * time.Millisecond)
+	assert.Empty(t, webhook.received(), "a live run fires no transition")
+
+	scheduler.Refresh(context.Background())
+	assert.Eventually(t, func() bool { return len(webhook.received()) == 2 }, time.Second, 5*time.Millisecond,
+		"the run of the polling fires the transitions of the overall status and of the database")
+}
+
+func TestNotifier_GivesUp(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	notifier := NewNotifier([]Webhook{{URL: server.URL}}, NotifierConfig{Backoff: time.Millisecond})
+	err := notifier.deliver(&notifier.webhooks[0], []byte(`{}`))
+	notifier.Close()
+
+	assert.EqualError(t, err, "unexpected status code 400")
+	assert.Equal(t, 1, calls, "a 4xx response is not retried")
+}
+
+func TestParseSeverity(t *testing.T) {
+	severity, err := ParseSeverity("warning")
+	assert.NoError(t, err)
+	assert.Equal(t, SeverityWarning, severity)
+
+	_, err = ParseSeverity("fatal")
+	assert.Error(t, err)
+}
@@ -0,0 +1,167 @@
+package healthcheck
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultPostgresProbeQuery is the query run by the deep PostgresSQL check when none is configured
+const DefaultPostgresProbeQuery = "SELECT 1"
+
+// replicationLagQuery reads the replication lag in seconds. The time since the last replayed transaction
+// keeps growing while the primary is idle, so a replica that replayed everything it received reports none.
+const replicationLagQuery = "SELECT CASE WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0 " +
+	"ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0) END"
+
+// PostgresThresholds configures the deep PostgresSQL check. A zero threshold disables its signal.
+type PostgresThresholds struct {
+	// ProbeQuery is run on every check, DefaultPostgresProbeQuery when empty
+	ProbeQuery string
+	// MaxWaitCount degrades the check when more connections than this had to wait
+	// for a free one in the pool since the previous check
+	MaxWaitCount int64
+	// MaxInUseRatio degrades the check when the share of connections in use reaches it, from 0 to 1.
+	// It only applies when the pool has a maximum of open connections.
+	MaxInUseRatio float64
+	// MaxReplicationLag degrades the check when the server is a replica further behind its primary
+	MaxReplicationLag time.Duration
+	// DegradeOnReadOnly degrades the check when the server is in recovery or only accepts reads
+	DegradeOnReadOnly bool
+}
+
+// PostgresChecker checks the PostgresSQL database/sql client, use NewPostgresChecker to build it
+type PostgresChecker struct {
+	DB *sql.DB
+	// Deep enables the probe query, pool, recovery and replication signals, only a ping is made when nil
+	Deep *PostgresThresholds
+
+	mu            sync.Mutex
+	lastWaitCount int64
+}
+
+// NewPostgresChecker creates a PostgresChecker for db. The pool waits that happened before are not counted,
+// so that a checker rebuilt on a reload does not report every wait since the process started.
+func NewPostgresChecker(db *sql.DB, deep *PostgresThresholds) *PostgresChecker {
+	return &PostgresChecker{DB: db, Deep: deep, lastWaitCount: db.Stats().WaitCount}
+}
+
+// Check performs a health check for PostgresSQL database/sql client
+func (pc *PostgresChecker) Check(ctx context.Context) error {
+	_, err := pc.CheckDetails(ctx)
+	return err
+}
+
+// CheckDetails performs a health check for PostgresSQL database/sql client.
+// In deep mode it also reports the pool usage, whether the server is in recovery or read-only
+// and its replication lag, degrading the check when they cross the thresholds.
+func (pc *PostgresChecker) CheckDetails(ctx context.Context) (map[string]any, error) {
+	if pc.Deep == nil {
+		return nil, pc.DB.PingContext(ctx)
+	}
+
+	if err := pc.probe(ctx); err != nil {
+		return nil, err
+	}
+
+	stats := pc.DB.Stats()
+	details := map[string]any{
+		"openConnections":    stats.OpenConnections,
+		"inUse":              stats.InUse,
+		"idle":               stats.Idle,
+		"maxOpenConnections": stats.MaxOpenConnections,
+		"waitCount":          stats.WaitCount,
+	}
+
+	var inRecovery, readOnly bool
+	err := pc.DB.QueryRowContext(ctx,
+		"SELECT pg_is_in_recovery(), current_setting('transaction_read_only') = 'on'").
+		Scan(&inRecovery, &readOnly)
+	if err != nil {
+		return details, fmt.Errorf("failed to read the recovery state: %w", err)
+	}
+
+	details["inRecovery"] = inRecovery
+	details["readOnly"] = readOnly
+
+	var lag time.Duration
+	if inRecovery {
+		if lag, err = pc.replicationLag(ctx); err != nil {
+			return details, err
+		}
+
+		details["replicationLagSeconds"] = lag.Seconds()
+	}
+
+	if warnings := pc.warnings(stats, inRecovery || readOnly, lag); len(warnings) > 0 {
+		return details, fmt.Errorf("%w: %s", ErrDegraded, strings.Join(warnings, "; "))
+	}
+
+	return details, nil
+}
+
+// Version returns the version of the PostgresSQL server
+func (pc *PostgresChecker) Version(ctx context.Context) (string, error) {
+	var version string
+	err := pc.DB.QueryRowContext(ctx, "SHOW server_version").Scan(&version)
+	return version, err
+}
+
+// probe runs the probe query, discarding its rows
+func (pc *PostgresChecker) probe(ctx context.Context) error {
+	query := pc.Deep.ProbeQuery
+	if query == "" {
+		query = DefaultPostgresProbeQuery
+	}
+
+	if _, err := pc.DB.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("probe query failed: %w", err)
+	}
+
+	return nil
+}
+
+// replicationLag returns how far behind its primary a replica is
+func (pc *PostgresChecker) replicationLag(ctx context.Context) (time.Duration, error) {
+	var seconds float64
+	if err := pc.DB.QueryRowContext(ctx, replicationLagQuery).Scan(&seconds); err != nil {
+		return 0, fmt.Errorf("failed to read the replication lag: %w", err)
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// warnings evaluates the signals of the deep check against the thresholds
+func (pc *PostgresChecker) warnings(stats sql.DBStats, readOnly bool, lag time.Duration) []string {
+	var warnings []string
+
+	pc.mu.Lock()
+	waited := stats.WaitCount - pc.lastWaitCount
+	pc.lastWaitCount = stats.WaitCount
+	pc.mu.Unlock()
+
+	if pc.Deep.MaxWaitCount > 0 && waited > pc.Deep.MaxWaitCount {
+		warnings = append(warnings, fmt.Sprintf("%d connections waited for the pool", waited))
+	}
+
+	if pc.Deep.MaxInUseRatio > 0 && stats.MaxOpenConnections > 0 {
+		ratio := float64(stats.InUse) / float64(stats.MaxOpenConnections)
+		if ratio >= pc.Deep.MaxInUseRatio {
+			warnings = append(warnings, fmt.Sprintf("%d of %d connections in use", stats.InUse,
+				stats.MaxOpenConnections))
+		}
+	}
+
+	if pc.Deep.DegradeOnReadOnly && readOnly {
+		warnings = append(warnings, "server is read-only")
+	}
+
+	if pc.Deep.MaxReplicationLag > 0 && lag > pc.Deep.MaxReplicationLag {
+		warnings = append(warnings, fmt.Sprintf("replication lag of %v", lag.Round(time.Millisecond)))
+	}
+
+	return warnings
+}
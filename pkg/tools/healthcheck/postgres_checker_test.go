@@ -0,0 +1,175 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const recoveryQuery = "SELECT pg_is_in_recovery(), current_setting('transaction_read_only') = 'on'"
+
+func TestPostgresChecker_CheckDetails(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ping only", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectPing()
+
+		details, err := (&PostgresChecker{DB: db}).CheckDetails(ctx)
+		assert.NoError(t, err)
+		assert.Nil(t, details)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("healthy primary", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("SELECT id FROM beers LIMIT 1").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(recoveryQuery).WillReturnRows(sqlmock.NewRows([]string{"r", "ro"}).AddRow(false, false))
+
+		checker := &PostgresChecker{DB: db, Deep: &PostgresThresholds{
+			ProbeQuery:        "SELECT id FROM beers LIMIT 1",
+			MaxReplicationLag: time.Second,
+			DegradeOnReadOnly: true,
+		}}
+		details, err := checker.CheckDetails(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, false, details["inRecovery"])
+		assert.Equal(t, false, details["readOnly"])
+		assert.NotContains(t, details, "replicationLagSeconds")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("lagging replica", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(DefaultPostgresProbeQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(recoveryQuery).WillReturnRows(sqlmock.NewRows([]string{"r", "ro"}).AddRow(true, true))
+		mock.ExpectQuery(replicationLagQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"lag"}).AddRow(30.5))
+
+		checker := &PostgresChecker{DB: db, Deep: &PostgresThresholds{
+			MaxReplicationLag: 10 * time.Second,
+			DegradeOnReadOnly: true,
+		}}
+		details, err := checker.CheckDetails(ctx)
+
+		assert.ErrorIs(t, err, ErrDegraded)
+		assert.Contains(t, err.Error(), "server is read-only")
+		assert.Contains(t, err.Error(), "replication lag of 30.5s")
+		assert.Equal(t, 30.5, details["replicationLagSeconds"])
+	})
+
+	t.Run("idle replica caught up", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(DefaultPostgresProbeQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(recoveryQuery).WillReturnRows(sqlmock.NewRows([]string{"r", "ro"}).AddRow(true, true))
+		mock.ExpectQuery(replicationLagQuery).WillReturnRows(sqlmock.NewRows([]string{"lag"}).AddRow(0))
+
+		checker := NewPostgresChecker(db, &PostgresThresholds{MaxReplicationLag: 10 * time.Second})
+		details, err := checker.CheckDetails(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, details["replicationLagSeconds"])
+		assert.Contains(t, replicationLagQuery, "pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn()")
+	})
+
+	t.Run("pool saturated", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		assert.NoError(t, err)
+		defer db.Close()
+		db.SetMaxOpenConns(2)
+
+		conn, err := db.Conn(ctx)
+		assert.NoError(t, err)
+		defer conn.Close()
+
+		mock.ExpectExec(DefaultPostgresProbeQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(recoveryQuery).WillReturnRows(sqlmock.NewRows([]string{"r", "ro"}).AddRow(false, false))
+
+		checker := &PostgresChecker{DB: db, Deep: &PostgresThresholds{MaxInUseRatio: 0.5}}
+		_, err = checker.CheckDetails(ctx)
+
+		assert.ErrorIs(t, err, ErrDegraded)
+		assert.Contains(t, err.Error(), "1 of 2 connections in use")
+	})
+
+	t.Run("waits before the checker was built", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		assert.NoError(t, err)
+		defer db.Close()
+		db.SetMaxOpenConns(1)
+
+		for range 2 {
+			want := db.Stats().WaitCount + 1
+			conn, err := db.Conn(ctx)
+			assert.NoError(t, err)
+			waited := make(chan struct{})
+			go func() {
+				defer close(waited)
+				if next, err := db.Conn(ctx); err == nil {
+					_ = next.Close()
+				}
+			}()
+			assert.Eventually(t, func() bool { return db.Stats().WaitCount == want }, time.Second, time.Millisecond)
+			_ = conn.Close()
+			<-waited
+		}
+		assert.Equal(t, int64(2), db.Stats().WaitCount)
+
+		mock.ExpectExec(DefaultPostgresProbeQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(recoveryQuery).WillReturnRows(sqlmock.NewRows([]string{"r", "ro"}).AddRow(false, false))
+
+		_, err = NewPostgresChecker(db, &PostgresThresholds{MaxWaitCount: 1}).CheckDetails(ctx)
+		assert.NoError(t, err, "the waits before the checker was built are not counted")
+	})
+
+	t.Run("probe failing", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(DefaultPostgresProbeQuery).WillReturnError(errors.New("relation does not exist"))
+
+		_, err = (&PostgresChecker{DB: db, Deep: &PostgresThresholds{}}).CheckDetails(ctx)
+
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrDegraded)
+	})
+}
+
+func TestRegistry_CheckerHealth_Degraded(t *testing.T) {
+	registry := NewRegistry()
+	assert.NoError(t, registry.Register(Config{
+		Name:     "primary-db",
+		Critical: true,
+		Checker: CheckerFunc(func(context.Context) error {
+			return fmt.Errorf("%w: server is read-only", ErrDegraded)
+		}),
+	}))
+
+	resp := registry.CheckerHealth(context.Background())
+
+	assert.Equal(t, StatusDegraded, resp.OverallStatus)
+	assert.Equal(t, 0.5, resp.Score)
+	assert.Equal(t, StatusDegraded, resp.Checks[0].Status)
+	assert.Equal(t, "degraded: server is read-only", resp.Checks[0].Error)
+	assert.NotNil(t, resp.Checks[0].LastSuccess)
+	assert.Zero(t, resp.Checks[0].ConsecutiveFailures)
+}
@@ -0,0 +1,90 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/samuskitchen/go-health-checker/pkg/tools/broker"
+)
+
+// QueueThresholds configures the inspection of a queue by the RabbitMQ check. A zero threshold disables it.
+type QueueThresholds struct {
+	// Name is the name of the queue, it must already exist
+	Name string `yaml:"name"`
+	// DegradedMessages degrades the check when more messages than this are waiting in the queue
+	DegradedMessages int `yaml:"degradedMessages"`
+	// UnhealthyMessages fails the check when more messages than this are waiting in the queue
+	UnhealthyMessages int `yaml:"unhealthyMessages"`
+	// AllowNoConsumers keeps the check healthy when nobody consumes the queue, it is degraded otherwise
+	AllowNoConsumers bool `yaml:"allowNoConsumers"`
+}
+
+// RabbitMQChecker checks the RabbitMQ connection and the depth and consumers of the configured queues
+type RabbitMQChecker struct {
+	Client broker.Client
+	// Queues are inspected on every check, only the connection is checked when empty
+	Queues []QueueThresholds
+}
+
+// Check performs a health check on RabbitMQ
+func (rc *RabbitMQChecker) Check(ctx context.Context) error {
+	_, err := rc.CheckDetails(ctx)
+	return err
+}
+
+// CheckDetails performs a health check on RabbitMQ and reports the messages and consumers of every queue.
+// A queue that cannot be inspected or has too many messages fails the check,
+// one that is backing up or has no consumers degrades it.
+func (rc *RabbitMQChecker) CheckDetails(_ context.Context) (map[string]any, error) {
+	if err := rc.Client.Ping(); err != nil {
+		return nil, err
+	}
+
+	if len(rc.Queues) == 0 {
+		return nil, nil
+	}
+
+	details := make(map[string]any, len(rc.Queues))
+	var failures, warnings []string
+
+	for _, thresholds := range rc.Queues {
+		queue, err := rc.Client.InspectQueue(thresholds.Name)
+		if err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+
+		details[thresholds.Name] = map[string]int{
+			"messages":  queue.Messages,
+			"consumers": queue.Consumers,
+		}
+
+		switch {
+		case thresholds.UnhealthyMessages > 0 && queue.Messages > thresholds.UnhealthyMessages:
+			failures = append(failures, fmt.Sprintf("queue %q has %d messages", thresholds.Name, queue.Messages))
+		case thresholds.DegradedMessages > 0 && queue.Messages > thresholds.DegradedMessages:
+			warnings = append(warnings, fmt.Sprintf("queue %q has %d messages", thresholds.Name, queue.Messages))
+		}
+
+		if queue.Consumers == 0 && !thresholds.AllowNoConsumers {
+			warnings = append(warnings, fmt.Sprintf("queue %q has no consumers", thresholds.Name))
+		}
+	}
+
+	if len(failures) > 0 {
+		return details, errors.New(strings.Join(append(failures, warnings...), "; "))
+	}
+
+	if len(warnings) > 0 {
+		return details, fmt.Errorf("%w: %s", ErrDegraded, strings.Join(warnings, "; "))
+	}
+
+	return details, nil
+}
+
+// Version returns the product and version advertised by the broker
+func (rc *RabbitMQChecker) Version(_ context.Context) (string, error) {
+	return rc.Client.ServerVersion()
+}
@@ -0,0 +1,72 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	_mockToolsBroker "github.com/samuskitchen/go-health-checker/pkg/tools/mocks/broker"
+	tools "github.com/samuskitchen/go-health-checker/pkg/tools/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRabbitMQChecker_CheckDetails(t *testing.T) {
+	ctx := context.Background()
+	queues := []QueueThresholds{
+		{Name: "beers", DegradedMessages: 100, UnhealthyMessages: 1000},
+		{Name: "audit", AllowNoConsumers: true},
+	}
+
+	t.Run("healthy", func(t *testing.T) {
+		client := _mockToolsBroker.NewMockClient(t)
+		client.On("Ping").Return(nil)
+		client.On("InspectQueue", "beers").Return(tools.QueueInfo{Name: "beers", Messages: 5, Consumers: 2}, nil)
+		client.On("InspectQueue", "audit").Return(tools.QueueInfo{Name: "audit", Messages: 40}, nil)
+
+		details, err := (&RabbitMQChecker{Client: client, Queues: queues}).CheckDetails(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"beers": map[string]int{"messages": 5, "consumers": 2},
+			"audit": map[string]int{"messages": 40, "consumers": 0},
+		}, details)
+	})
+
+	t.Run("degraded", func(t *testing.T) {
+		client := _mockToolsBroker.NewMockClient(t)
+		client.On("Ping").Return(nil)
+		client.On("InspectQueue", "beers").Return(tools.QueueInfo{Name: "beers", Messages: 150}, nil)
+		client.On("InspectQueue", "audit").Return(tools.QueueInfo{Name: "audit"}, nil)
+
+		_, err := (&RabbitMQChecker{Client: client, Queues: queues}).CheckDetails(ctx)
+
+		assert.ErrorIs(t, err, ErrDegraded)
+		assert.Contains(t, err.Error(), `queue "beers" has 150 messages`)
+		assert.Contains(t, err.Error(), `queue "beers" has no consumers`)
+	})
+
+	t.Run("unhealthy", func(t *testing.T) {
+		client := _mockToolsBroker.NewMockClient(t)
+		client.On("Ping").Return(nil)
+		client.On("InspectQueue", "beers").Return(tools.QueueInfo{Name: "beers", Messages: 5000, Consumers: 1}, nil)
+		client.On("InspectQueue", "audit").Return(tools.QueueInfo{}, errors.New(`failed to inspect queue "audit"`))
+
+		details, err := (&RabbitMQChecker{Client: client, Queues: queues}).CheckDetails(ctx)
+
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrDegraded)
+		assert.Contains(t, err.Error(), `queue "beers" has 5000 messages`)
+		assert.Contains(t, err.Error(), `failed to inspect queue "audit"`)
+		assert.Contains(t, details, "beers")
+	})
+
+	t.Run("connection closed", func(t *testing.T) {
+		client := _mockToolsBroker.NewMockClient(t)
+		client.On("Ping").Return(errors.New("rabbitmq connection is closed"))
+
+		_, err := (&RabbitMQChecker{Client: client, Queues: queues}).CheckDetails(ctx)
+
+		assert.EqualError(t, err, "rabbitmq connection is closed")
+	})
+}
@@ -0,0 +1,30 @@
+package healthcheck
+
+import (
+	"context"
+
+	"github.com/samuskitchen/go-health-checker/pkg/tools/datastore"
+)
+
+// RedisChecker checks the Redis client connection
+type RedisChecker struct {
+	Client datastore.IClient
+}
+
+// Check performs a health check on a Redis client with a PING, bounded by ctx when the client supports it
+func (rc *RedisChecker) Check(ctx context.Context) error {
+	if client, ok := rc.Client.(datastore.IContextClient); ok {
+		return client.PingContext(ctx)
+	}
+
+	return rc.Client.Ping()
+}
+
+// Version returns the version of the Redis server, bounded by ctx when the client supports it
+func (rc *RedisChecker) Version(ctx context.Context) (string, error) {
+	if client, ok := rc.Client.(datastore.IContextClient); ok {
+		return client.ServerVersionContext(ctx)
+	}
+
+	return rc.Client.ServerVersion()
+}
@@ -0,0 +1,52 @@
+package healthcheck
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samuskitchen/go-health-checker/pkg/tools/datastore"
+	tools "github.com/samuskitchen/go-health-checker/pkg/tools/models"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClients_Registry_Redis(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	client, err := datastore.NewClientRedis(tools.RedisConfig{Address: server.Addr()})
+	assert.NoError(t, err)
+	defer client.Disconnect(context.Background())
+
+	clients := Clients{
+		RedisClient: client,
+		Critical:    map[string]bool{"redis-connection": true},
+	}
+
+	resp := clients.Registry().CheckerHealth(context.Background())
+	assert.Equal(t, StatusAvailable, resp.OverallStatus)
+	assert.Equal(t, "Redis", resp.Checks[0].Component)
+
+	server.SetError("LOADING Redis is loading the dataset in memory")
+
+	resp = clients.Registry().CheckerHealth(context.Background())
+	assert.Equal(t, StatusUnavailable, resp.OverallStatus)
+	assert.Equal(t, "LOADING Redis is loading the dataset in memory", resp.Checks[0].Error)
+}
+
+func TestRedisChecker_Context(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	client, err := datastore.NewClientRedis(tools.RedisConfig{Address: server.Addr()})
+	assert.NoError(t, err)
+	defer client.Disconnect(context.Background())
+
+	checker := &RedisChecker{Client: client}
+	assert.NoError(t, checker.Check(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, checker.Check(ctx), context.Canceled, "the check honors the deadline of the run")
+	_, err = checker.Version(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,78 @@
+package healthcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Format is how a Response is written by Write
+type Format string
+
+const (
+	// FormatJSON writes the response as the health endpoints serve it
+	FormatJSON Format = "json"
+	// FormatTable writes one line per check followed by the overall status, for people reading a terminal
+	FormatTable Format = "table"
+)
+
+// ParseFormat parses a format name, reporting false when it is unknown
+func ParseFormat(value string) (Format, bool) {
+	switch Format(strings.ToLower(strings.TrimSpace(value))) {
+	case FormatJSON:
+		return FormatJSON, true
+	case FormatTable:
+		return FormatTable, true
+	default:
+		return "", false
+	}
+}
+
+// Write writes the response to w in the given format, JSON when it is unknown
+func (r Response) Write(w io.Writer, format Format) error {
+	if format == FormatTable {
+		return r.writeTable(w)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(r)
+}
+
+// writeTable writes the component, status, criticality, duration and error of every check
+func (r Response) writeTable(w io.Writer) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(table, "COMPONENT\tSTATUS\tCRITICAL\tDURATION\tERROR")
+
+	for _, check := range r.Checks {
+		_, _ = fmt.Fprintf(table, "%s\t%s\t%t\t%sms\t%s\n", check.Component, check.Status, check.Critical,
+			strconv.FormatFloat(check.DurationMs, 'f', 1, 64), check.Error)
+	}
+
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\nOverall status: %s (score %.2f)\n", r.OverallStatus, r.Score)
+	return err
+}
+
+// ExitCode returns the exit code of a one-shot health check:
+// 1 when the service is Unavailable, or Degraded when strict, 0 otherwise
+func (r Response) ExitCode(strict bool) int {
+	switch r.OverallStatus {
+	case StatusUnavailable:
+		return 1
+	case StatusDegraded:
+		if strict {
+			return 1
+		}
+		return 0
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,65 @@
+package healthcheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFormat(t *testing.T) {
+	format, ok := ParseFormat(" Table ")
+	assert.True(t, ok)
+	assert.Equal(t, FormatTable, format)
+
+	_, ok = ParseFormat("yaml")
+	assert.False(t, ok)
+}
+
+func TestResponse_Write(t *testing.T) {
+	resp := Response{
+		OverallStatus: StatusDegraded,
+		Score:         0.5,
+		Checks: []Health{
+			{Component: "postgresql-sql", Status: StatusOK, Critical: true, DurationMs: 1.25},
+			{Component: "RabbitMQ", Status: StatusDegraded, Error: "degraded: queue backing up"},
+		},
+	}
+
+	var out bytes.Buffer
+	assert.NoError(t, resp.Write(&out, FormatJSON))
+	var decoded Response
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, resp, decoded)
+
+	out.Reset()
+	assert.NoError(t, resp.Write(&out, FormatTable))
+	lines := strings.Split(out.String(), "\n")
+	assert.Equal(t, "COMPONENT       STATUS               CRITICAL  DURATION  ERROR", lines[0])
+	assert.Equal(t, "postgresql-sql  OK                   true      1.2ms", strings.TrimSpace(lines[1]))
+	assert.Equal(t, "RabbitMQ        Partially Available  false     0.0ms     degraded: queue backing up", lines[2])
+	assert.Equal(t, "Overall status: Partially Available (score 0.50)", lines[4])
+}
+
+func TestResponse_ExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   Status
+		strict   bool
+		expected int
+	}{
+		{name: "available", status: StatusAvailable, expected: 0},
+		{name: "unknown", status: StatusUnknown, expected: 0},
+		{name: "degraded", status: StatusDegraded, expected: 0},
+		{name: "degraded strict", status: StatusDegraded, strict: true, expected: 1},
+		{name: "unavailable", status: StatusUnavailable, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Response{OverallStatus: tt.status}.ExitCode(tt.strict))
+		})
+	}
+}
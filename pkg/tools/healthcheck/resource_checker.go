@@ -0,0 +1,223 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"runtime"
+
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// Thresholds degrades a check when a value reaches Warn and fails it when it reaches Critical.
+// A zero threshold disables it.
+type Thresholds struct {
+	Warn     float64 `yaml:"warn"`
+	Critical float64 `yaml:"critical"`
+}
+
+// Default thresholds of the resource checks
+var (
+	// DefaultDiskUsage is the share of a disk in use, in percent, from which a disk check degrades and fails
+	DefaultDiskUsage = Thresholds{Warn: 80, Critical: 95}
+	// DefaultFileDescriptorUsage is the share of the open files limit in use, in percent,
+	// from which the file descriptors check degrades and fails
+	DefaultFileDescriptorUsage = Thresholds{Warn: 80, Critical: 95}
+	// DefaultGoroutines is the number of goroutines from which the goroutines check degrades
+	DefaultGoroutines = Thresholds{Warn: 10000}
+)
+
+// evaluate compares value, described by what and shown with unit, against the thresholds
+func (t Thresholds) evaluate(what string, value float64, unit string) error {
+	if t.Critical > 0 && value >= t.Critical {
+		return fmt.Errorf("%s is %g%s, reaching the critical threshold of %g%s", what, value, unit, t.Critical, unit)
+	}
+
+	if t.Warn > 0 && value >= t.Warn {
+		return fmt.Errorf("%w: %s is %g%s, reaching the warning threshold of %g%s", ErrDegraded, what, value, unit,
+			t.Warn, unit)
+	}
+
+	return nil
+}
+
+// ResourceThresholds configures the checks of the resources used by the process
+type ResourceThresholds struct {
+	// RSS is the resident memory of the process, in MB
+	RSS Thresholds
+	// Heap is the memory allocated on the Go heap, in MB
+	Heap Thresholds
+	// FileDescriptors is the share of the open files limit in use, in percent
+	FileDescriptors Thresholds
+	// Goroutines is the number of goroutines
+	Goroutines Thresholds
+}
+
+// DiskChecker checks the free space of the filesystem holding a path
+type DiskChecker struct {
+	// Path is any path on the filesystem, e.g. "/var/lib/data"
+	Path string
+	// Usage is the share of the filesystem in use, in percent
+	Usage Thresholds
+}
+
+// Check performs a health check on the filesystem
+func (dc *DiskChecker) Check(ctx context.Context) error {
+	_, err := dc.CheckDetails(ctx)
+	return err
+}
+
+// CheckDetails reports the size, free space and usage of the filesystem
+func (dc *DiskChecker) CheckDetails(ctx context.Context) (map[string]any, error) {
+	usage, err := disk.UsageWithContext(ctx, dc.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	usedPercent := round(usage.UsedPercent)
+	details := map[string]any{
+		"path":        dc.Path,
+		"totalBytes":  usage.Total,
+		"freeBytes":   usage.Free,
+		"usedPercent": usedPercent,
+	}
+
+	return details, dc.Usage.evaluate("disk usage of "+dc.Path, usedPercent, "%")
+}
+
+// MemoryChecker checks the resident memory of the process and the memory allocated on the Go heap
+type MemoryChecker struct {
+	// RSS is the resident memory of the process, in MB
+	RSS Thresholds
+	// Heap is the memory allocated on the Go heap, in MB
+	Heap Thresholds
+}
+
+// Check performs a health check on the memory of the process
+func (mc *MemoryChecker) Check(ctx context.Context) error {
+	_, err := mc.CheckDetails(ctx)
+	return err
+}
+
+// CheckDetails reports the resident and heap memory of the process in MB
+func (mc *MemoryChecker) CheckDetails(ctx context.Context) (map[string]any, error) {
+	proc, err := currentProcess(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	memory, err := proc.MemoryInfoWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+
+	rss := round(float64(memory.RSS) / (1 << 20))
+	heap := round(float64(stats.HeapAlloc) / (1 << 20))
+	details := map[string]any{
+		"rssMB":  rss,
+		"heapMB": heap,
+	}
+
+	return details, worst(mc.RSS.evaluate("resident memory", rss, "MB"), mc.Heap.evaluate("heap memory", heap, "MB"))
+}
+
+// FileDescriptorChecker checks the files open by the process against its open files limit
+type FileDescriptorChecker struct {
+	// Usage is the share of the open files limit in use, in percent
+	Usage Thresholds
+}
+
+// Check performs a health check on the file descriptors of the process
+func (fc *FileDescriptorChecker) Check(ctx context.Context) error {
+	_, err := fc.CheckDetails(ctx)
+	return err
+}
+
+// CheckDetails reports the files open by the process, its open files limit and the share of it in use.
+// Only the number of open files is reported when the limit is unknown or unlimited.
+func (fc *FileDescriptorChecker) CheckDetails(ctx context.Context) (map[string]any, error) {
+	proc, err := currentProcess(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	open, err := proc.NumFDsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	details := map[string]any{"open": open}
+
+	limits, err := proc.RlimitWithContext(ctx)
+	if err != nil {
+		return details, err
+	}
+
+	for _, limit := range limits {
+		if limit.Resource != process.RLIMIT_NOFILE || limit.Soft == 0 || limit.Soft >= math.MaxInt64 {
+			continue
+		}
+
+		usedPercent := round(float64(open) / float64(limit.Soft) * 100)
+		details["limit"] = limit.Soft
+		details["usedPercent"] = usedPercent
+
+		return details, fc.Usage.evaluate("file descriptor usage", usedPercent, "%")
+	}
+
+	return details, nil
+}
+
+// GoroutineChecker checks the number of goroutines of the process, a steady growth usually being a leak
+type GoroutineChecker struct {
+	Count Thresholds
+}
+
+// Check performs a health check on the number of goroutines
+func (gc *GoroutineChecker) Check(ctx context.Context) error {
+	_, err := gc.CheckDetails(ctx)
+	return err
+}
+
+// CheckDetails reports the number of goroutines
+func (gc *GoroutineChecker) CheckDetails(_ context.Context) (map[string]any, error) {
+	count := runtime.NumGoroutine()
+
+	return map[string]any{"goroutines": count}, gc.Count.evaluate("goroutine count", float64(count), "")
+}
+
+// currentProcess returns a handle on the running process
+func currentProcess(ctx context.Context) (*process.Process, error) {
+	return process.NewProcessWithContext(ctx, int32(os.Getpid()))
+}
+
+// worst returns the first error failing the check, or else the first one degrading it
+func worst(errs ...error) error {
+	var degraded error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		if !errors.Is(err, ErrDegraded) {
+			return err
+		}
+
+		if degraded == nil {
+			degraded = err
+		}
+	}
+
+	return degraded
+}
+
+// round rounds value to one decimal place
+func round(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -0,0 +1,84 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThresholds_evaluate(t *testing.T) {
+	thresholds := Thresholds{Warn: 80, Critical: 95}
+
+	assert.NoError(t, thresholds.evaluate("disk usage", 79.9, "%"))
+
+	err := thresholds.evaluate("disk usage", 80, "%")
+	assert.ErrorIs(t, err, ErrDegraded)
+	assert.EqualError(t, err, "degraded: disk usage is 80%, reaching the warning threshold of 80%")
+
+	err = thresholds.evaluate("disk usage", 97.5, "%")
+	assert.NotErrorIs(t, err, ErrDegraded)
+	assert.EqualError(t, err, "disk usage is 97.5%, reaching the critical threshold of 95%")
+
+	assert.NoError(t, Thresholds{}.evaluate("disk usage", 100, "%"))
+}
+
+func TestResourceCheckers_CheckDetails(t *testing.T) {
+	ctx := context.Background()
+
+	// The usage of the temporary filesystem is unknown, the thresholds are covered by TestThresholds_evaluate
+	details, err := (&DiskChecker{Path: t.TempDir()}).CheckDetails(ctx)
+	assert.NoError(t, err)
+	assert.Contains(t, details, "usedPercent")
+
+	details, err = (&MemoryChecker{}).CheckDetails(ctx)
+	assert.NoError(t, err)
+	assert.Contains(t, details, "rssMB")
+	assert.Contains(t, details, "heapMB")
+
+	_, err = (&MemoryChecker{RSS: Thresholds{Warn: 0.0001}}).CheckDetails(ctx)
+	assert.ErrorIs(t, err, ErrDegraded)
+
+	details, err = (&GoroutineChecker{Count: Thresholds{Critical: 1}}).CheckDetails(ctx)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrDegraded)
+	assert.Contains(t, details, "goroutines")
+}
+
+func TestFileDescriptorChecker_CheckDetails(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the open files and their limit are only read on Linux")
+	}
+
+	details, err := (&FileDescriptorChecker{}).CheckDetails(context.Background())
+	assert.NoError(t, err)
+	assert.Contains(t, details, "open")
+}
+
+func TestWorst(t *testing.T) {
+	degraded := errors.Join(ErrDegraded, errors.New("slow"))
+	failed := errors.New("down")
+
+	assert.NoError(t, worst(nil, nil))
+	assert.Equal(t, degraded, worst(nil, degraded))
+	assert.Equal(t, failed, worst(degraded, failed))
+}
+
+func TestClients_Registry_Resources(t *testing.T) {
+	clients := Clients{
+		Disks:     map[string]*DiskChecker{"data-disk": {Path: t.TempDir()}},
+		Resources: &ResourceThresholds{Goroutines: Thresholds{Warn: 1}},
+	}
+	resp := clients.Registry().CheckerHealth(context.Background())
+
+	components := make([]string, 0, len(resp.Checks))
+	for _, check := range resp.Checks {
+		components = append(components, check.Component)
+	}
+
+	assert.Equal(t, []string{"data-disk", "process-memory", "file-descriptors", "goroutines"}, components)
+	assert.Equal(t, StatusDegraded, resp.Checks[3].Status)
+	assert.Equal(t, StatusDegraded, resp.OverallStatus)
+}
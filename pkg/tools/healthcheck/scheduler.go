@@ -0,0 +1,210 @@
+package healthcheck
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// stalledRuns is the number of missed intervals after which a running scheduler is considered stalled
+const stalledRuns = 3
+
+// Scheduler runs the checks of a registry in the background and keeps the latest response,
+// so that health endpoints can answer from memory instead of hitting every dependency
+type Scheduler struct {
+	registry *Registry
+	interval time.Duration
+	deadline time.Duration
+
+	// runMu serializes the runs, so that a slow run never overwrites the results of a newer one
+	runMu     sync.Mutex
+	mu        sync.RWMutex
+	snapshot  Response
+	lastRun   time.Time
+	running   bool
+	observers []Observer
+
+	cancel  context.CancelFunc
+	done    chan struct{}
+	refresh chan struct{}
+}
+
+// NewScheduler creates a scheduler that runs the checks of registry every interval,
+// each run bounded by deadline. A zero interval disables background polling.
+func NewScheduler(registry *Registry, interval, deadline time.Duration) *Scheduler {
+	if deadline <= 0 {
+		deadline = DefaultTimeout
+	}
+
+	return &Scheduler{
+		registry: registry,
+		interval: interval,
+		deadline: deadline,
+		refresh:  make(chan struct{}, 1),
+	}
+}
+
+// Start runs the checks once and then on every interval until Stop is called.
+// It does nothing when polling is disabled or the scheduler is already running.
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	if s.interval <= 0 || s.running {
+		s.mu.Unlock()
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	s.running = true
+	s.mu.Unlock()
+
+	go s.loop(ctx)
+}
+
+// Stop stops the background polling and waits for the current run to finish
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return
+	}
+
+	s.running = false
+	cancel, done := s.cancel, s.done
+	s.mu.Unlock()
+
+	cancel()
+	<-done
+}
+
+// AddObserver registers an observer notified with the response of every run
+func (s *Scheduler) AddObserver(observer Observer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.observers = append(s.observers, observer)
+}
+
+// SetRegistry replaces the checks run from the next run on, e.g. when the configuration is reloaded.
+// A run in progress completes with the previous checks. The checks kept under the same name
+// keep their history of successes and failures.
+func (s *Scheduler) SetRegistry(registry *Registry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	registry.inherit(s.registry)
+	s.registry = registry
+}
+
+// Run performs every check right away, stores the response as the latest snapshot
+// and notifies the observers. It waits for the run in progress to finish before starting the checks,
+// so that the latest response stored and observed is always the freshest one.
+func (s *Scheduler) Run(ctx context.Context) Response {
+	s.runMu.Lock()
+	defer s.runMu.Unlock()
+
+	resp := s.check(ctx)
+
+	s.mu.Lock()
+	s.snapshot = resp
+	s.lastRun = time.Now()
+	observers := s.observers
+	s.mu.Unlock()
+
+	for _, observer := range observers {
+		observer.ObserveHealth(resp)
+	}
+
+	return resp
+}
+
+// Refresh makes the background polling run the checks right away instead of waiting for the next tick,
+// e.g. after the configuration is reloaded, so that the observers only follow the runs of the polling.
+// It runs them itself when the polling is not running.
+func (s *Scheduler) Refresh(ctx context.Context) {
+	s.mu.RLock()
+	running := s.running
+	s.mu.RUnlock()
+
+	if !running {
+		s.Run(ctx)
+		return
+	}
+
+	// A pending refresh already covers this one
+	select {
+	case s.refresh <- struct{}{}:
+	default:
+	}
+}
+
+// Check performs every check right away on behalf of a caller, e.g. a request asking for fresh results,
+// and returns the response without storing it nor notifying the observers, which only follow the runs
+// of the scheduler. It waits for the run in progress to finish first.
+// The checks are bounded by the deadline of a run but not canceled with ctx,
+// so that a caller going away does not record them as timed out.
+func (s *Scheduler) Check(ctx context.Context) Response {
+	s.runMu.Lock()
+	defer s.runMu.Unlock()
+
+	return s.check(context.WithoutCancel(ctx))
+}
+
+// check performs every check of the current registry within the deadline of a run, runMu must be held
+func (s *Scheduler) check(ctx context.Context) Response {
+	ctx, cancel := context.WithTimeout(ctx, s.deadline)
+	defer cancel()
+
+	s.mu.RLock()
+	registry := s.registry
+	s.mu.RUnlock()
+
+	return registry.CheckerHealth(ctx)
+}
+
+// Snapshot returns the latest response of the background polling.
+// It reports false when the scheduler is not running or has not finished its first run yet.
+func (s *Scheduler) Snapshot() (Response, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.running || s.lastRun.IsZero() {
+		return Response{}, false
+	}
+
+	return s.snapshot, true
+}
+
+// Stalled reports whether the background polling stopped producing results,
+// which means the process is wedged
+func (s *Scheduler) Stalled() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.running || s.lastRun.IsZero() {
+		return false
+	}
+
+	return time.Since(s.lastRun) > stalledRuns*s.interval+s.deadline
+}
+
+// loop runs the checks on every tick until ctx is canceled
+func (s *Scheduler) loop(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.Run(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.refresh:
+			ticker.Reset(s.interval)
+		}
+	}
+}
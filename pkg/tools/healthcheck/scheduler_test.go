@@ -0,0 +1,140 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduler(t *testing.T) {
+	var runs atomic.Int32
+
+	registry := NewRegistry()
+	assert.NoError(t, registry.Register(Config{
+		Name: "counter",
+		Checker: CheckerFunc(func(context.Context) error {
+			runs.Add(1)
+			return nil
+		}),
+	}))
+
+	scheduler := NewScheduler(registry, 20*time.Millisecond, time.Second)
+
+	_, ok := scheduler.Snapshot()
+	assert.False(t, ok, "no snapshot before the scheduler starts")
+
+	scheduler.Start()
+	scheduler.Start() // starting twice is a no-op
+
+	assert.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, 5*time.Millisecond)
+
+	snapshot, ok := scheduler.Snapshot()
+	assert.True(t, ok)
+	assert.Equal(t, StatusAvailable, snapshot.OverallStatus)
+	assert.False(t, scheduler.Stalled())
+
+	scheduler.Stop()
+	scheduler.Stop() // stopping twice is a no-op
+
+	stoppedAt := runs.Load()
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, stoppedAt, runs.Load(), "no runs after Stop")
+
+	_, ok = scheduler.Snapshot()
+	assert.False(t, ok, "no snapshot once stopped")
+}
+
+func TestScheduler_Disabled(t *testing.T) {
+	registry := NewRegistry()
+	assert.NoError(t, registry.Register(Config{
+		Name:    "ok",
+		Checker: CheckerFunc(func(context.Context) error { return nil }),
+	}))
+
+	scheduler := NewScheduler(registry, 0, time.Second)
+	scheduler.Start()
+	defer scheduler.Stop()
+
+	_, ok := scheduler.Snapshot()
+	assert.False(t, ok)
+
+	resp := scheduler.Run(context.Background())
+	assert.Equal(t, StatusAvailable, resp.OverallStatus)
+}
+
+// observerFunc adapts a function to the Observer interface
+type observerFunc func(Response)
+
+func (f observerFunc) ObserveHealth(resp Response) { f(resp) }
+
+func TestScheduler_Check(t *testing.T) {
+	release := make(chan struct{})
+	var running atomic.Int32
+
+	registry := NewRegistry()
+	assert.NoError(t, registry.Register(Config{
+		Name: "slow",
+		Checker: CheckerFunc(func(context.Context) error {
+			assert.Equal(t, int32(1), running.Add(1), "the runs never overlap")
+			defer running.Add(-1)
+			<-release
+			return nil
+		}),
+	}))
+
+	var observed atomic.Int32
+	scheduler := NewScheduler(registry, time.Hour, time.Second)
+	scheduler.AddObserver(observerFunc(func(Response) { observed.Add(1) }))
+
+	done := make(chan Response)
+	go func() { done <- scheduler.Check(context.Background()) }()
+	go func() { done <- scheduler.Run(context.Background()) }()
+
+	release <- struct{}{}
+	release <- struct{}{}
+	<-done
+	<-done
+
+	assert.Equal(t, int32(1), observed.Load(), "only the run of the scheduler is observed")
+
+	observed.Store(0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	go func() { release <- struct{}{} }()
+	resp := scheduler.Check(ctx)
+	assert.Equal(t, StatusAvailable, resp.OverallStatus, "a caller going away does not time out the checks")
+	assert.Zero(t, observed.Load())
+}
+
+func TestScheduler_SetRegistry(t *testing.T) {
+	failing := NewRegistry()
+	assert.NoError(t, failing.Register(Config{
+		Name:    "database",
+		Checker: CheckerFunc(func(context.Context) error { return errors.New("connection refused") }),
+	}))
+
+	scheduler := NewScheduler(failing, 0, time.Second)
+	scheduler.Run(context.Background())
+	resp := scheduler.Run(context.Background())
+	assert.Equal(t, 2, resp.Checks[0].ConsecutiveFailures)
+
+	reloaded := NewRegistry()
+	assert.NoError(t, reloaded.Register(Config{
+		Name:    "database",
+		Checker: CheckerFunc(func(context.Context) error { return errors.New("connection refused") }),
+	}))
+	assert.NoError(t, reloaded.Register(Config{
+		Name:    "cache",
+		Checker: CheckerFunc(func(context.Context) error { return nil }),
+	}))
+
+	scheduler.SetRegistry(reloaded)
+	resp = scheduler.Run(context.Background())
+	assert.Len(t, resp.Checks, 2)
+	assert.Equal(t, 3, resp.Checks[0].ConsecutiveFailures, "the history of a check survives a reload")
+	assert.Equal(t, StatusOK, resp.Checks[1].Status)
+}
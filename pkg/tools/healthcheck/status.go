@@ -0,0 +1,115 @@
+package healthcheck
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hellofresh/health-go/v5"
+)
+
+// Status represents the status of a single check or of the service as a whole
+type Status string
+
+// Check statuses, they share their values with health-go
+const (
+	// StatusOK reports a check that passed
+	StatusOK Status = Status(health.StatusOK)
+	// StatusTimeout reports a check that did not finish in time
+	StatusTimeout Status = Status(health.StatusTimeout)
+)
+
+// Overall statuses
+const (
+	// StatusAvailable reports that every check passed
+	StatusAvailable Status = "Available"
+	// StatusDegraded reports that some checks failed but the service can still do its work.
+	// A single check is Degraded when its dependency answers but not as expected, see ErrDegraded.
+	StatusDegraded Status = Status(health.StatusPartiallyAvailable)
+	// StatusUnavailable reports a failed check, or that the service cannot do its work
+	StatusUnavailable Status = Status(health.StatusUnavailable)
+	// StatusUnknown reports that there was nothing to check
+	StatusUnknown Status = "unknown"
+)
+
+// passed reports whether a check with this status got an answer from its dependency
+func (s Status) passed() bool {
+	return s == StatusOK || s == StatusDegraded
+}
+
+// StatusCodes maps the overall status to the HTTP status code of the health endpoints
+type StatusCodes struct {
+	// Degraded is the code returned while the service is degraded, http.StatusOK when zero.
+	// http.StatusMultiStatus lets load balancers tell a degraded instance from a healthy one.
+	Degraded int
+}
+
+// HTTPStatus returns the HTTP status code for the given overall status:
+// Unavailable maps to 503, Degraded to the configured code and anything else to 200
+func (sc StatusCodes) HTTPStatus(status Status) int {
+	switch status {
+	case StatusUnavailable:
+		return http.StatusServiceUnavailable
+	case StatusDegraded:
+		if sc.Degraded != 0 {
+			return sc.Degraded
+		}
+		return http.StatusOK
+	default:
+		return http.StatusOK
+	}
+}
+
+// CheckHTTPStatus returns the HTTP status code for the status of a single check:
+// OK maps to 200, Degraded to the configured code and anything else to 503
+func (sc StatusCodes) CheckHTTPStatus(status Status) int {
+	switch status {
+	case StatusOK:
+		return http.StatusOK
+	case StatusDegraded:
+		return sc.HTTPStatus(status)
+	default:
+		return http.StatusServiceUnavailable
+	}
+}
+
+// Verbosity controls how much detail a health response exposes
+type Verbosity string
+
+const (
+	// VerbosityFull exposes every detail of the checks, including error messages
+	VerbosityFull Verbosity = "full"
+	// VerbositySummary only exposes the status of the service and of each component,
+	// for public callers that must not see versions or error messages
+	VerbositySummary Verbosity = "summary"
+)
+
+// ParseVerbosity parses a verbosity name, reporting false when it is unknown
+func ParseVerbosity(value string) (Verbosity, bool) {
+	switch Verbosity(strings.ToLower(strings.TrimSpace(value))) {
+	case VerbosityFull:
+		return VerbosityFull, true
+	case VerbositySummary:
+		return VerbositySummary, true
+	default:
+		return "", false
+	}
+}
+
+// WithVerbosity returns a copy of the response that only holds the details allowed by verbosity
+func (r Response) WithVerbosity(verbosity Verbosity) Response {
+	if verbosity != VerbositySummary {
+		return r
+	}
+
+	checks := make([]Health, len(r.Checks))
+	for i, check := range r.Checks {
+		checks[i] = Health{
+			Status:    check.Status,
+			Component: check.Component,
+			Critical:  check.Critical,
+		}
+	}
+
+	r.Checks = checks
+	return r
+}
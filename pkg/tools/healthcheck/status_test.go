@@ -0,0 +1,78 @@
+package healthcheck
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusCodes_HTTPStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		codes    StatusCodes
+		status   Status
+		expected int
+	}{
+		{name: "available", status: StatusAvailable, expected: http.StatusOK},
+		{name: "unknown", status: StatusUnknown, expected: http.StatusOK},
+		{name: "unavailable", status: StatusUnavailable, expected: http.StatusServiceUnavailable},
+		{name: "degraded default", status: StatusDegraded, expected: http.StatusOK},
+		{
+			name:     "degraded multi status",
+			codes:    StatusCodes{Degraded: http.StatusMultiStatus},
+			status:   StatusDegraded,
+			expected: http.StatusMultiStatus,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.codes.HTTPStatus(tt.status))
+		})
+	}
+}
+
+func TestStatusCodes_CheckHTTPStatus(t *testing.T) {
+	codes := StatusCodes{Degraded: http.StatusMultiStatus}
+
+	assert.Equal(t, http.StatusOK, codes.CheckHTTPStatus(StatusOK))
+	assert.Equal(t, http.StatusMultiStatus, codes.CheckHTTPStatus(StatusDegraded))
+	assert.Equal(t, http.StatusServiceUnavailable, codes.CheckHTTPStatus(StatusUnavailable))
+	assert.Equal(t, http.StatusServiceUnavailable, codes.CheckHTTPStatus(StatusTimeout))
+}
+
+func TestResponse_WithVerbosity(t *testing.T) {
+	now := time.Now()
+	resp := Response{
+		OverallStatus: StatusDegraded,
+		Score:         0.5,
+		Checks: []Health{{
+			Status:              StatusUnavailable,
+			Component:           "postgresql-sql",
+			Version:             "16.2",
+			Critical:            true,
+			DurationMs:          12.5,
+			Error:               "password authentication failed",
+			LastFailure:         &now,
+			ConsecutiveFailures: 3,
+		}},
+	}
+
+	assert.Equal(t, resp, resp.WithVerbosity(VerbosityFull))
+
+	summary := resp.WithVerbosity(VerbositySummary)
+	assert.Equal(t, StatusDegraded, summary.OverallStatus)
+	assert.Equal(t, []Health{{Status: StatusUnavailable, Component: "postgresql-sql", Critical: true}}, summary.Checks)
+	assert.Equal(t, "password authentication failed", resp.Checks[0].Error, "the original response is not modified")
+}
+
+func TestParseVerbosity(t *testing.T) {
+	verbosity, ok := ParseVerbosity(" Summary ")
+	assert.True(t, ok)
+	assert.Equal(t, VerbositySummary, verbosity)
+
+	_, ok = ParseVerbosity("debug")
+	assert.False(t, ok)
+}
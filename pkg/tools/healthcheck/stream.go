@@ -0,0 +1,225 @@
+package healthcheck
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Stream event types
+const (
+	// StreamEventSnapshot carries the full response, sent on connect and whenever the set of checks changes
+	StreamEventSnapshot = "snapshot"
+	// StreamEventChange carries the checks whose status or latency bucket changed since the previous run
+	StreamEventChange = "change"
+)
+
+const (
+	// DefaultStreamBuffer is the number of events queued per subscriber when no buffer is configured
+	DefaultStreamBuffer = 16
+	// DefaultStreamReplay is the number of past events kept for the subscribers that reconnect
+	DefaultStreamReplay = 64
+)
+
+// DefaultLatencyBuckets are the upper bounds in milliseconds of the latency buckets of the checks.
+// A check moving to another bucket is streamed as a change even when its status did not change.
+var DefaultLatencyBuckets = []float64{10, 50, 100, 250, 500, 1000, 2500, 5000}
+
+// StreamEvent is an update of the health of the service sent to the subscribers of a Stream
+type StreamEvent struct {
+	// ID grows with every event, subscribers send back the last one they got to resume after a reconnect
+	ID   uint64
+	Type string
+	// Data is the whole response for a snapshot, only the checks that changed otherwise
+	Data Response
+}
+
+// StreamConfig configures a Stream
+type StreamConfig struct {
+	// Buffer is the number of events queued per subscriber, DefaultStreamBuffer when zero.
+	// A subscriber whose buffer is full is dropped instead of blocking the checks.
+	Buffer int
+	// Replay is the number of past events kept for reconnecting subscribers, DefaultStreamReplay when zero
+	Replay int
+	// LatencyBuckets are the bucket bounds in milliseconds, DefaultLatencyBuckets when empty
+	LatencyBuckets []float64
+}
+
+// Stream is an Observer that turns the runs of a Scheduler into events for live subscribers,
+// e.g. the clients of a Server-Sent Events endpoint
+type Stream struct {
+	mu          sync.Mutex
+	config      StreamConfig
+	seq         uint64
+	observed    bool
+	latest      Response
+	states      map[string]streamState
+	events      []StreamEvent
+	subscribers map[*Subscription]struct{}
+}
+
+// streamState is what a Stream compares between two runs of a check
+type streamState struct {
+	status Status
+	bucket int
+}
+
+// Subscription receives the events of a Stream until it is unsubscribed or falls behind
+type Subscription struct {
+	events chan StreamEvent
+}
+
+// Events returns the events of the subscription. It is closed when the subscriber fell behind and was dropped,
+// the subscriber can then subscribe again with the ID of the last event it got.
+func (sub *Subscription) Events() <-chan StreamEvent {
+	return sub.events
+}
+
+// NewStream creates a Stream, applying the defaults of config
+func NewStream(config StreamConfig) *Stream {
+	if config.Buffer <= 0 {
+		config.Buffer = DefaultStreamBuffer
+	}
+
+	if config.Replay <= 0 {
+		config.Replay = DefaultStreamReplay
+	}
+
+	if len(config.LatencyBuckets) == 0 {
+		config.LatencyBuckets = DefaultLatencyBuckets
+	}
+
+	return &Stream{
+		config:      config,
+		states:      map[string]streamState{},
+		subscribers: map[*Subscription]struct{}{},
+	}
+}
+
+// ObserveHealth sends the changes since the previous run to every subscriber
+func (s *Stream) ObserveHealth(resp Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	event, changed := s.diff(resp)
+	s.latest = resp
+	s.observed = true
+	if !changed {
+		return
+	}
+
+	s.seq++
+	event.ID = s.seq
+
+	s.events = append(s.events, event)
+	if len(s.events) > s.config.Replay {
+		s.events = append(s.events[:0:0], s.events[len(s.events)-s.config.Replay:]...)
+	}
+
+	for sub := range s.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			// Never blocks the checks on a slow subscriber, it catches up when it reconnects
+			s.drop(sub)
+		}
+	}
+}
+
+// Ready reports whether the stream has observed a run yet
+func (s *Stream) Ready() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.observed
+}
+
+// Subscribe registers a subscriber and returns the events it must be sent first:
+// the events after lastEventID when they are still kept, a snapshot of the latest run otherwise
+func (s *Stream) Subscribe(lastEventID string) (*Subscription, []StreamEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub := &Subscription{events: make(chan StreamEvent, s.config.Buffer)}
+	s.subscribers[sub] = struct{}{}
+
+	return sub, s.replay(lastEventID)
+}
+
+// Unsubscribe stops sending events to sub, it is a no-op when sub has already been dropped
+func (s *Stream) Unsubscribe(sub *Subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subscribers[sub]; ok {
+		s.drop(sub)
+	}
+}
+
+// drop removes sub and closes its events, the lock must be held
+func (s *Stream) drop(sub *Subscription) {
+	delete(s.subscribers, sub)
+	close(sub.events)
+}
+
+// replay returns the events after lastEventID, or a snapshot when they are not all kept, the lock must be held
+func (s *Stream) replay(lastEventID string) []StreamEvent {
+	if !s.observed {
+		return nil
+	}
+
+	if id, err := strconv.ParseUint(lastEventID, 10, 64); err == nil && id <= s.seq &&
+		(len(s.events) == 0 || id+1 >= s.events[0].ID) {
+		var missed []StreamEvent
+		for _, event := range s.events {
+			if event.ID > id {
+				missed = append(missed, event)
+			}
+		}
+
+		return missed
+	}
+
+	return []StreamEvent{{ID: s.seq, Type: StreamEventSnapshot, Data: s.latest}}
+}
+
+// diff builds the event for resp and records its states, reporting false when nothing changed.
+// The lock must be held.
+func (s *Stream) diff(resp Response) (StreamEvent, bool) {
+	states := make(map[string]streamState, len(resp.Checks))
+	for _, check := range resp.Checks {
+		states[check.Component] = streamState{status: check.Status, bucket: s.bucket(check.DurationMs)}
+	}
+
+	previous := s.states
+	s.states = states
+
+	if !s.observed || len(previous) != len(states) {
+		return StreamEvent{Type: StreamEventSnapshot, Data: resp}, true
+	}
+
+	changes := resp
+	changes.Checks = nil
+	for _, check := range resp.Checks {
+		state, ok := previous[check.Component]
+		if !ok {
+			// A check was replaced by another one, e.g. after a reload
+			return StreamEvent{Type: StreamEventSnapshot, Data: resp}, true
+		}
+
+		if state != states[check.Component] {
+			changes.Checks = append(changes.Checks, check)
+		}
+	}
+
+	if len(changes.Checks) == 0 && resp.OverallStatus == s.latest.OverallStatus {
+		return StreamEvent{}, false
+	}
+
+	return StreamEvent{Type: StreamEventChange, Data: changes}, true
+}
+
+// bucket returns the index of the latency bucket of a duration in milliseconds
+func (s *Stream) bucket(durationMs float64) int {
+	return sort.SearchFloat64s(s.config.LatencyBuckets, durationMs)
+}
@@ -0,0 +1,116 @@
+package healthcheck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// streamResponse builds a response whose Redis check has the given status and duration
+func streamResponse(status Status, durationMs float64) Response {
+	return Response{
+		OverallStatus: StatusAvailable,
+		Checks: []Health{
+			{Component: "Redis", Status: status, DurationMs: durationMs},
+			{Component: "RabbitMQ", Status: StatusOK, DurationMs: 2},
+		},
+	}
+}
+
+func TestStream_ObserveHealth(t *testing.T) {
+	stream := NewStream(StreamConfig{})
+	assert.False(t, stream.Ready())
+
+	sub, replay := stream.Subscribe("")
+	defer stream.Unsubscribe(sub)
+	assert.Empty(t, replay, "nothing to send before the first run")
+
+	stream.ObserveHealth(streamResponse(StatusOK, 3))
+	assert.True(t, stream.Ready())
+	event := <-sub.Events()
+	assert.Equal(t, StreamEvent{ID: 1, Type: StreamEventSnapshot, Data: streamResponse(StatusOK, 3)}, event)
+
+	stream.ObserveHealth(streamResponse(StatusOK, 8))
+	assert.Empty(t, sub.Events(), "the latency stayed in the same bucket")
+
+	stream.ObserveHealth(streamResponse(StatusOK, 300))
+	event = <-sub.Events()
+	assert.Equal(t, uint64(2), event.ID)
+	assert.Equal(t, StreamEventChange, event.Type)
+	assert.Equal(t, []Health{{Component: "Redis", Status: StatusOK, DurationMs: 300}}, event.Data.Checks)
+
+	changed := streamResponse(StatusUnavailable, 300)
+	changed.OverallStatus = StatusDegraded
+	stream.ObserveHealth(changed)
+	event = <-sub.Events()
+	assert.Equal(t, StatusDegraded, event.Data.OverallStatus)
+	assert.Equal(t, []Health{{Component: "Redis", Status: StatusUnavailable, DurationMs: 300}}, event.Data.Checks)
+
+	reloaded := Response{OverallStatus: StatusAvailable, Checks: []Health{{Component: "Hazelcast", Status: StatusOK}}}
+	stream.ObserveHealth(reloaded)
+	event = <-sub.Events()
+	assert.Equal(t, StreamEvent{ID: 4, Type: StreamEventSnapshot, Data: reloaded}, event,
+		"a new set of checks is sent whole")
+}
+
+func TestStream_Subscribe(t *testing.T) {
+	stream := NewStream(StreamConfig{Replay: 2})
+	for _, duration := range []float64{1, 20, 60, 150} {
+		stream.ObserveHealth(streamResponse(StatusOK, duration))
+	}
+
+	tests := []struct {
+		name        string
+		lastEventID string
+		expectedIDs []uint64
+		snapshot    bool
+	}{
+		{name: "first connection", lastEventID: "", expectedIDs: []uint64{4}, snapshot: true},
+		{name: "missed events kept", lastEventID: "2", expectedIDs: []uint64{3, 4}},
+		{name: "up to date", lastEventID: "4", expectedIDs: nil},
+		{name: "missed events dropped", lastEventID: "1", expectedIDs: []uint64{4}, snapshot: true},
+		{name: "from another process", lastEventID: "42", expectedIDs: []uint64{4}, snapshot: true},
+		{name: "malformed", lastEventID: "abc", expectedIDs: []uint64{4}, snapshot: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, replay := stream.Subscribe(tt.lastEventID)
+			defer stream.Unsubscribe(sub)
+
+			var ids []uint64
+			for _, event := range replay {
+				ids = append(ids, event.ID)
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+
+			if tt.snapshot {
+				assert.Equal(t, StreamEventSnapshot, replay[0].Type)
+				assert.Equal(t, streamResponse(StatusOK, 150), replay[0].Data)
+			}
+		})
+	}
+}
+
+func TestStream_SlowSubscriber(t *testing.T) {
+	stream := NewStream(StreamConfig{Buffer: 1})
+	slow, _ := stream.Subscribe("")
+	fast, _ := stream.Subscribe("")
+
+	for i, duration := range []float64{1, 20, 60} {
+		stream.ObserveHealth(streamResponse(StatusOK, duration))
+		event := <-fast.Events()
+		assert.Equal(t, uint64(i+1), event.ID, "a slow subscriber does not hold back the others")
+	}
+
+	event, ok := <-slow.Events()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), event.ID)
+	_, ok = <-slow.Events()
+	assert.False(t, ok, "the slow subscriber is dropped once its buffer is full")
+
+	stream.Unsubscribe(slow) // unsubscribing a dropped subscriber is a no-op
+	stream.Unsubscribe(fast)
+	_, ok = <-fast.Events()
+	assert.False(t, ok)
+}
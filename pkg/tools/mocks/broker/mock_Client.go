@@ -5,6 +5,9 @@
 package _mocks
 
 import (
+	"context"
+
+	tools "github.com/samuskitchen/go-health-checker/pkg/tools/models"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -148,6 +151,66 @@ func (_c *MockClient_ConnectLocal_Call) RunAndReturn(run func(host string, port
 	return _c
 }
 
+// InspectQueue provides a mock function for the type MockClient
+func (_mock *MockClient) InspectQueue(name string) (tools.QueueInfo, error) {
+	ret := _mock.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InspectQueue")
+	}
+
+	var r0 tools.QueueInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (tools.QueueInfo, error)); ok {
+		return returnFunc(name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) tools.QueueInfo); ok {
+		r0 = returnFunc(name)
+	} else {
+		r0 = ret.Get(0).(tools.QueueInfo)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_InspectQueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InspectQueue'
+type MockClient_InspectQueue_Call struct {
+	*mock.Call
+}
+
+// InspectQueue is a helper method to define mock.On call
+//   - name string
+func (_e *MockClient_Expecter) InspectQueue(name interface{}) *MockClient_InspectQueue_Call {
+	return &MockClient_InspectQueue_Call{Call: _e.mock.On("InspectQueue", name)}
+}
+
+func (_c *MockClient_InspectQueue_Call) Run(run func(name string)) *MockClient_InspectQueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_InspectQueue_Call) Return(queueInfo tools.QueueInfo, err error) *MockClient_InspectQueue_Call {
+	_c.Call.Return(queueInfo, err)
+	return _c
+}
+
+func (_c *MockClient_InspectQueue_Call) RunAndReturn(run func(name string) (tools.QueueInfo, error)) *MockClient_InspectQueue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Ping provides a mock function for the type MockClient
 func (_mock *MockClient) Ping() error {
 	ret := _mock.Called()
@@ -191,3 +254,125 @@ func (_c *MockClient_Ping_Call) RunAndReturn(run func() error) *MockClient_Ping_
 	_c.Call.Return(run)
 	return _c
 }
+
+// Publish provides a mock function for the type MockClient
+func (_mock *MockClient) Publish(ctx context.Context, exchange string, routingKey string, body []byte) error {
+	ret := _mock.Called(ctx, exchange, routingKey, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []byte) error); ok {
+		r0 = returnFunc(ctx, exchange, routingKey, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockClient_Publish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Publish'
+type MockClient_Publish_Call struct {
+	*mock.Call
+}
+
+// Publish is a helper method to define mock.On call
+//   - ctx context.Context
+//   - exchange string
+//   - routingKey string
+//   - body []byte
+func (_e *MockClient_Expecter) Publish(ctx interface{}, exchange interface{}, routingKey interface{}, body interface{}) *MockClient_Publish_Call {
+	return &MockClient_Publish_Call{Call: _e.mock.On("Publish", ctx, exchange, routingKey, body)}
+}
+
+func (_c *MockClient_Publish_Call) Run(run func(ctx context.Context, exchange string, routingKey string, body []byte)) *MockClient_Publish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []byte
+		if args[3] != nil {
+			arg3 = args[3].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_Publish_Call) Return(err error) *MockClient_Publish_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockClient_Publish_Call) RunAndReturn(run func(ctx context.Context, exchange string, routingKey string, body []byte) error) *MockClient_Publish_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ServerVersion provides a mock function for the type MockClient
+func (_mock *MockClient) ServerVersion() (string, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ServerVersion")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() (string, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_ServerVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ServerVersion'
+type MockClient_ServerVersion_Call struct {
+	*mock.Call
+}
+
+// ServerVersion is a helper method to define mock.On call
+func (_e *MockClient_Expecter) ServerVersion() *MockClient_ServerVersion_Call {
+	return &MockClient_ServerVersion_Call{Call: _e.mock.On("ServerVersion")}
+}
+
+func (_c *MockClient_ServerVersion_Call) Run(run func()) *MockClient_ServerVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockClient_ServerVersion_Call) Return(s string, err error) *MockClient_ServerVersion_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockClient_ServerVersion_Call) RunAndReturn(run func() (string, error)) *MockClient_ServerVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
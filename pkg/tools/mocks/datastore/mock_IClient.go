@@ -131,3 +131,56 @@ func (_c *MockIClient_Ping_Call) RunAndReturn(run func() error) *MockIClient_Pin
 	_c.Call.Return(run)
 	return _c
 }
+
+// ServerVersion provides a mock function for the type MockIClient
+func (_mock *MockIClient) ServerVersion() (string, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ServerVersion")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() (string, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIClient_ServerVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ServerVersion'
+type MockIClient_ServerVersion_Call struct {
+	*mock.Call
+}
+
+// ServerVersion is a helper method to define mock.On call
+func (_e *MockIClient_Expecter) ServerVersion() *MockIClient_ServerVersion_Call {
+	return &MockIClient_ServerVersion_Call{Call: _e.mock.On("ServerVersion")}
+}
+
+func (_c *MockIClient_ServerVersion_Call) Run(run func()) *MockIClient_ServerVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockIClient_ServerVersion_Call) Return(s string, err error) *MockIClient_ServerVersion_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockIClient_ServerVersion_Call) RunAndReturn(run func() (string, error)) *MockIClient_ServerVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -1,8 +1,13 @@
 // Package tools package provides utilities and frameworks for configuring and connecting to
-// a Hazelcast cluster generically.
+// a Hazelcast cluster or a Redis server generically.
 package tools
 
-import "github.com/hazelcast/hazelcast-go-client"
+import (
+	"crypto/tls"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/redis/go-redis/v9"
+)
 
 // Config contains the settings for connecting to Hazelcast.
 type Config struct {
@@ -23,3 +28,30 @@ func (c Config) ToHazelcastConfig() hazelcast.Config {
 
 	return cfg
 }
+
+// RedisConfig contains the settings for connecting to Redis.
+type RedisConfig struct {
+	Address    string
+	Username   string
+	Password   string
+	DB         int
+	ClientName string
+	TLS        bool
+}
+
+// ToRedisOptions converts our configuration structure to that of Redis.
+func (c RedisConfig) ToRedisOptions() *redis.Options {
+	opts := &redis.Options{
+		Addr:       c.Address,
+		Username:   c.Username,
+		Password:   c.Password,
+		DB:         c.DB,
+		ClientName: c.ClientName,
+	}
+
+	if c.TLS {
+		opts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	return opts
+}
@@ -8,3 +8,10 @@ type Params struct {
 	Password string
 	Vhost    string
 }
+
+// QueueInfo is the state of a RabbitMQ queue
+type QueueInfo struct {
+	Name      string
+	Messages  int
+	Consumers int
+}